@@ -1,21 +1,69 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"app/internal/config"
 	"app/internal/quote"
 )
 
+const shutdownTimeout = 30 * time.Second
+
 func main() {
-	router := quote.RouterAPIInitializer()
+	if err := run(config.Load()); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run serves the API until the process is interrupted, then it drains the requests and closes the database pool.
+func run(cfg config.Config) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	pool, err := quote.NewDatabasePool(ctx, cfg.Storage)
+	if err != nil {
+		return fmt.Errorf("Failed to connect to the database: %w", err)
+	}
+	if pool != nil {
+		defer pool.Close()
+	}
+
+	deps, err := quote.NewDependencies(cfg.Storage, pool)
+	if err != nil {
+		return fmt.Errorf("Failed to initialize the stores: %w", err)
+	}
+
+	router := quote.RouterAPIInitializer(cfg, deps)
 	if router == nil {
-		log.Fatal("Failed to initialize router")
+		return errors.New("Failed to initialize router")
 	}
 
-	address := ":8080"
-	log.Printf("Starting server on %s...", address)
-	if err := http.ListenAndServe(address, router); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	server := &http.Server{Addr: cfg.Address, Handler: router}
+	serveErr := make(chan error, 1)
+	go func() {
+		log.Printf("Starting server on %s...", cfg.Address)
+		serveErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serveErr:
+		return fmt.Errorf("Server failed to start: %w", err)
+	case <-ctx.Done():
 	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("Server shutdown failed: %w", err)
+	}
+
+	return nil
 }
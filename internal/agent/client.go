@@ -0,0 +1,24 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+type Client struct {
+}
+
+var (
+	ErrUnavailable = errors.New("agent service is unavailable")
+)
+
+func NewClient() *Client {
+	return &Client{}
+}
+
+func (c *Client) IsAssigned(ctx context.Context, agentID uuid.UUID, customerID uuid.UUID) (bool, error) {
+	return false, fmt.Errorf("Agent::Client::IsAssigned : %w: not implemented", ErrUnavailable)
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+)
+
+type (
+	jwks struct {
+		url             string
+		client          *http.Client
+		refreshInterval time.Duration
+
+		mu        sync.RWMutex
+		keys      map[string]*rsa.PublicKey
+		fetchedAt time.Time
+	}
+
+	jwksDocument struct {
+		Keys []jwksKey `json:"keys"`
+	}
+
+	jwksKey struct {
+		Kid string `json:"kid"`
+		Kty string `json:"kty"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+	}
+)
+
+var (
+	errKeyNotFound = errors.New("signing key not found")
+)
+
+func newJWKS(url string, refreshInterval time.Duration) *jwks {
+	return &jwks{
+		url:             url,
+		client:          &http.Client{Timeout: 10 * time.Second},
+		refreshInterval: refreshInterval,
+		keys:            make(map[string]*rsa.PublicKey),
+	}
+}
+
+// Key returns the public key with the given id, fetching the key set again when the id is unknown
+// and the cached set is older than the refresh interval (keys are rotated by the identity provider).
+func (j *jwks) Key(ctx context.Context, kid string) (*rsa.PublicKey, error) {
+	j.mu.RLock()
+	key, ok := j.keys[kid]
+	stale := time.Since(j.fetchedAt) > j.refreshInterval
+	j.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+	if !stale {
+		return nil, fmt.Errorf("Auth::jwks::Key : %w: %s", errKeyNotFound, kid)
+	}
+
+	if err := j.fetch(ctx); err != nil {
+		return nil, fmt.Errorf("Auth::jwks::Key : %w", err)
+	}
+
+	j.mu.RLock()
+	defer j.mu.RUnlock()
+
+	key, ok = j.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("Auth::jwks::Key : %w: %s", errKeyNotFound, kid)
+	}
+
+	return key, nil
+}
+
+func (j *jwks) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, j.url, nil)
+	if err != nil {
+		return fmt.Errorf("Auth::jwks::fetch : %w", err)
+	}
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("Auth::jwks::fetch : %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("Auth::jwks::fetch : unexpected status %d", resp.StatusCode)
+	}
+
+	var document jwksDocument
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return fmt.Errorf("Auth::jwks::fetch : %w", err)
+	}
+
+	keys := make(map[string]*rsa.PublicKey, len(document.Keys))
+	for _, k := range document.Keys {
+		if k.Kty != "RSA" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return fmt.Errorf("Auth::jwks::fetch : %w", err)
+		}
+		keys[k.Kid] = key
+	}
+
+	j.mu.Lock()
+	j.keys = keys
+	j.fetchedAt = time.Now()
+	j.mu.Unlock()
+
+	return nil
+}
+
+func (k jwksKey) publicKey() (*rsa.PublicKey, error) {
+	n, err := base64.RawURLEncoding.DecodeString(k.N)
+	if err != nil {
+		return nil, fmt.Errorf("decode modulus of key %s: %w", k.Kid, err)
+	}
+
+	e, err := base64.RawURLEncoding.DecodeString(k.E)
+	if err != nil {
+		return nil, fmt.Errorf("decode exponent of key %s: %w", k.Kid, err)
+	}
+
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(n),
+		E: int(new(big.Int).SetBytes(e).Int64()),
+	}, nil
+}
@@ -0,0 +1,53 @@
+package auth
+
+import "slices"
+
+type Permission string
+
+const (
+	RoleSalesAgent   string = "sales_agent"
+	RoleSalesManager string = "sales_manager"
+
+	PermissionQuoteView      Permission = "quote:view"
+	PermissionQuoteEdit      Permission = "quote:edit"
+	PermissionQuoteDiscount  Permission = "quote:discount"
+	PermissionQuoteSubmit    Permission = "quote:submit"
+	PermissionQuoteOverride  Permission = "quote:override"
+	PermissionQuoteApprove   Permission = "quote:approve"
+	PermissionQuoteNegotiate Permission = "quote:negotiate"
+)
+
+var rolePermissions = map[string][]Permission{
+	RoleSalesAgent: {
+		PermissionQuoteView,
+		PermissionQuoteEdit,
+		PermissionQuoteSubmit,
+		PermissionQuoteOverride,
+		PermissionQuoteNegotiate,
+	},
+	RoleSalesManager: {
+		PermissionQuoteView,
+		PermissionQuoteEdit,
+		PermissionQuoteDiscount,
+		PermissionQuoteSubmit,
+		PermissionQuoteOverride,
+		PermissionQuoteApprove,
+		PermissionQuoteNegotiate,
+	},
+}
+
+// Can reports whether any of the principal roles grants the permission.
+func (p *Principal) Can(permission Permission) bool {
+	for _, role := range p.Roles {
+		if slices.Contains(rolePermissions[role], permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsAgent reports whether the principal acts as a sales agent.
+func (p *Principal) IsAgent() bool {
+	return p.HasRole(RoleSalesAgent) || p.HasRole(RoleSalesManager)
+}
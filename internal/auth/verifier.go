@@ -0,0 +1,118 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	RoleStaff string = "staff"
+)
+
+type (
+	Config struct {
+		// JWKSURL is the location of the identity provider key set used to verify RS256 tokens.
+		JWKSURL string
+		// StaticKey is a shared HS256 secret, meant for local testing only.
+		StaticKey string
+		Issuer    string
+		Audience  string
+	}
+
+	Principal struct {
+		Subject string
+		Roles   []string
+	}
+
+	claims struct {
+		jwt.RegisteredClaims
+		Roles []string `json:"roles"`
+	}
+
+	Verifier struct {
+		config Config
+		jwks   *jwks
+	}
+)
+
+var (
+	ErrTokenInvalid          = errors.New("token is invalid")
+	ErrVerifierNotConfigured = errors.New("token verifier is not configured")
+)
+
+func NewVerifier(config Config) *Verifier {
+	verifier := &Verifier{
+		config: config,
+	}
+	if config.JWKSURL != "" {
+		verifier.jwks = newJWKS(config.JWKSURL, time.Minute)
+	}
+
+	return verifier
+}
+
+// Verify validates the token signature and registered claims and returns the authenticated principal.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Principal, error) {
+	if v.jwks == nil && v.config.StaticKey == "" {
+		return nil, ErrVerifierNotConfigured
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(v.validMethods()),
+		jwt.WithExpirationRequired(),
+	}
+	if v.config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(v.config.Issuer))
+	}
+	if v.config.Audience != "" {
+		options = append(options, jwt.WithAudience(v.config.Audience))
+	}
+
+	var tokenClaims claims
+	_, err := jwt.ParseWithClaims(token, &tokenClaims, func(t *jwt.Token) (interface{}, error) {
+		return v.key(ctx, t)
+	}, options...)
+	if err != nil {
+		return nil, fmt.Errorf("Auth::Verifier::Verify : %w: %w", ErrTokenInvalid, err)
+	}
+
+	if tokenClaims.Subject == "" {
+		return nil, fmt.Errorf("Auth::Verifier::Verify : %w: missing subject", ErrTokenInvalid)
+	}
+
+	return &Principal{
+		Subject: tokenClaims.Subject,
+		Roles:   tokenClaims.Roles,
+	}, nil
+}
+
+func (v *Verifier) validMethods() []string {
+	methods := make([]string, 0, 2)
+	if v.jwks != nil {
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+	if v.config.StaticKey != "" {
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	return methods
+}
+
+func (v *Verifier) key(ctx context.Context, token *jwt.Token) (interface{}, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); ok {
+		return []byte(v.config.StaticKey), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	return v.jwks.Key(ctx, kid)
+}
+
+// HasRole reports whether the principal has been granted the role.
+func (p *Principal) HasRole(role string) bool {
+	return slices.Contains(p.Roles, role)
+}
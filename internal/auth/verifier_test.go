@@ -0,0 +1,106 @@
+package auth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"app/internal/auth"
+)
+
+func TestVerifierStaticKey(t *testing.T) {
+	// arrange
+	verifier := auth.NewVerifier(auth.Config{StaticKey: "secret"})
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   "customer",
+		"roles": []string{auth.RoleStaff},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	// act
+	principal, err := verifier.Verify(context.Background(), token)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "customer", principal.Subject)
+	assert.True(t, principal.HasRole(auth.RoleStaff))
+}
+
+func TestVerifierStaticKeyExpired(t *testing.T) {
+	// arrange
+	verifier := auth.NewVerifier(auth.Config{StaticKey: "secret"})
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "customer",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	}).SignedString([]byte("secret"))
+	assert.NoError(t, err)
+
+	// act
+	_, err = verifier.Verify(context.Background(), token)
+
+	// assert
+	assert.ErrorIs(t, err, auth.ErrTokenInvalid)
+}
+
+func TestVerifierJWKS(t *testing.T) {
+	// arrange
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{{
+				"kid": "key-1",
+				"kty": "RSA",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	}))
+	defer server.Close()
+
+	verifier := auth.NewVerifier(auth.Config{JWKSURL: server.URL, Issuer: "idp"})
+	signed := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"sub": "customer",
+		"iss": "idp",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+	signed.Header["kid"] = "key-1"
+	token, err := signed.SignedString(key)
+	assert.NoError(t, err)
+
+	// act
+	principal, err := verifier.Verify(context.Background(), token)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "customer", principal.Subject)
+	assert.False(t, principal.HasRole(auth.RoleStaff))
+}
+
+func TestVerifierRejectsHMACWhenOnlyJWKSConfigured(t *testing.T) {
+	// arrange
+	verifier := auth.NewVerifier(auth.Config{JWKSURL: "http://127.0.0.1:0"})
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": "customer",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(""))
+	assert.NoError(t, err)
+
+	// act
+	_, err = verifier.Verify(context.Background(), token)
+
+	// assert
+	assert.ErrorIs(t, err, auth.ErrTokenInvalid)
+}
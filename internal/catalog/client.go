@@ -3,6 +3,7 @@ package catalog
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -10,6 +11,7 @@ import (
 type (
 	Product struct {
 		ProductID uuid.UUID
+		SKU       string
 		Price     float64
 		TaxRateID string
 	}
@@ -18,10 +20,19 @@ type (
 	}
 )
 
+var (
+	ErrProductNotFound = errors.New("catalog product not found")
+	ErrUnavailable     = errors.New("product catalog is unavailable")
+)
+
 func NewClient() *Client {
 	return &Client{}
 }
 
 func (c *Client) GetProductByID(ctx context.Context, productID uuid.UUID) (*Product, error) {
-	return nil, errors.New("not implemented")
+	return nil, fmt.Errorf("Catalog::Client::GetProductByID : %w: not implemented", ErrUnavailable)
+}
+
+func (c *Client) GetProductBySKU(ctx context.Context, sku string) (*Product, error) {
+	return nil, fmt.Errorf("Catalog::Client::GetProductBySKU : %w: not implemented", ErrUnavailable)
 }
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/auth"
+	"app/internal/quote/document"
+	"app/internal/quote/domain"
+	"app/internal/quote/export"
+	"app/internal/quote/share"
+)
+
+const (
+	PersistenceSnapshot string = "snapshot"
+	PersistenceEvents   string = "events"
+
+	EventStoreMemory   string = "memory"
+	EventStorePostgres string = "postgres"
+
+	IdempotencyStoreMemory   string = "memory"
+	IdempotencyStorePostgres string = "postgres"
+
+	TemplateStoreMemory   string = "memory"
+	TemplateStorePostgres string = "postgres"
+
+	ShareStoreMemory   string = "memory"
+	ShareStorePostgres string = "postgres"
+)
+
+type (
+	Config struct {
+		Address  string
+		Auth     auth.Config
+		Quote    domain.Config
+		Document document.Config
+		Export   export.Config
+		Share    share.Config
+		Storage  Storage
+		// ValidateResponses checks every response against Openapi.yaml, meant for test environments.
+		ValidateResponses bool
+		// RequireIfMatch rejects quote changes without the If-Match header.
+		RequireIfMatch bool
+		// TrustedProxies are the addresses or CIDR ranges of the proxies whose forwarded client address is taken.
+		TrustedProxies []string
+	}
+
+	Storage struct {
+		// Persistence selects how quotes are stored: events in the event store, or snapshots in DynamoDB once its
+		// store is implemented.
+		Persistence      string
+		EventStore       string
+		DatabaseURL      string
+		SnapshotInterval int
+		// IdempotencyStore keeps the responses of requests sent with an Idempotency-Key header.
+		IdempotencyStore string
+		IdempotencyTTL   time.Duration
+		// TemplateStore keeps the quote templates managed by staff.
+		TemplateStore string
+		// ShareStore keeps the share links of quotes and their accesses.
+		ShareStore string
+	}
+)
+
+// Load reads the application configuration from the environment.
+func Load() Config {
+	companyName := getEnv("DOCUMENT_COMPANY_NAME", "Meisterwerk")
+	currency := getEnv("DOCUMENT_CURRENCY", "EUR")
+	validityDays := getEnvInt("QUOTE_VALIDITY_DAYS", 30)
+
+	return Config{
+		Address: getEnv("APP_ADDRESS", ":8080"),
+		Auth: auth.Config{
+			JWKSURL:   os.Getenv("AUTH_JWKS_URL"),
+			StaticKey: os.Getenv("AUTH_STATIC_KEY"),
+			Issuer:    os.Getenv("AUTH_ISSUER"),
+			Audience:  os.Getenv("AUTH_AUDIENCE"),
+		},
+		Quote: domain.Config{
+			PriceOverrideApprovalThreshold: getEnvFloat("QUOTE_PRICE_OVERRIDE_APPROVAL_THRESHOLD", 10),
+			MaxQuantity:                    getEnvInt("QUOTE_MAX_QUANTITY", 1000),
+			MaxLines:                       getEnvInt("QUOTE_MAX_LINES", 100),
+			PaymentMethods:                 getEnvList("QUOTE_PAYMENT_METHODS", []string{"card", "invoice", "bank_transfer"}),
+			NumberTenant:                   getEnv("QUOTE_NUMBER_TENANT", "default"),
+			NumberPrefix:                   getEnv("QUOTE_NUMBER_PREFIX", "Q"),
+			ShareTTL:                       getEnvDuration("QUOTE_SHARE_TTL", 7*24*time.Hour),
+			ShareMaxTTL:                    getEnvDuration("QUOTE_SHARE_MAX_TTL", 30*24*time.Hour),
+			TermsVersion:                   os.Getenv("QUOTE_TERMS_VERSION"),
+			AcceptanceSegments:             getEnvList("QUOTE_ACCEPTANCE_SEGMENTS", nil),
+		},
+		Document: document.Config{
+			CompanyName:    companyName,
+			CompanyAddress: os.Getenv("DOCUMENT_COMPANY_ADDRESS"),
+			Currency:       currency,
+			AccentColor:    os.Getenv("DOCUMENT_ACCENT_COLOR"),
+			ValidityDays:   validityDays,
+			TemplatePath:   os.Getenv("DOCUMENT_TEMPLATE"),
+		},
+		Export: export.Config{
+			SellerName:   companyName,
+			Currency:     currency,
+			ValidityDays: validityDays,
+			Timeout:      getEnvDuration("EXPORT_TIMEOUT", 30*time.Minute),
+		},
+		Share: share.Config{
+			Key: os.Getenv("QUOTE_SHARE_KEY"),
+		},
+		Storage: Storage{
+			Persistence:      getEnv("QUOTE_PERSISTENCE", PersistenceEvents),
+			EventStore:       getEnv("EVENT_STORE", EventStoreMemory),
+			DatabaseURL:      os.Getenv("DATABASE_URL"),
+			SnapshotInterval: getEnvInt("QUOTE_SNAPSHOT_INTERVAL", 50),
+			IdempotencyStore: getEnv("IDEMPOTENCY_STORE", IdempotencyStoreMemory),
+			IdempotencyTTL:   getEnvDuration("IDEMPOTENCY_KEY_TTL", 24*time.Hour),
+			TemplateStore:    getEnv("QUOTE_TEMPLATE_STORE", TemplateStoreMemory),
+			ShareStore:       getEnv("QUOTE_SHARE_STORE", ShareStoreMemory),
+		},
+		ValidateResponses: getEnvBool("OPENAPI_VALIDATE_RESPONSES", false),
+		RequireIfMatch:    getEnvBool("QUOTE_REQUIRE_IF_MATCH", false),
+		TrustedProxies:    getEnvList("TRUSTED_PROXIES", nil),
+	}
+}
+
+func getEnv(name string, fallback string) string {
+	if value, ok := os.LookupEnv(name); ok {
+		return value
+	}
+
+	return fallback
+}
+
+func getEnvFloat(name string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(name), 64)
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getEnvInt(name string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getEnvBool(name string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getEnvDuration(name string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(name))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+func getEnvList(name string, fallback []string) []string {
+	value := os.Getenv(name)
+	if value == "" {
+		return fallback
+	}
+
+	list := strings.Split(value, ",")
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+
+	return list
+}
@@ -3,6 +3,7 @@ package customer
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/google/uuid"
 )
@@ -10,10 +11,19 @@ import (
 type Client struct {
 }
 
+var (
+	ErrUnavailable = errors.New("customer service is unavailable")
+)
+
 func NewClient() *Client {
 	return &Client{}
 }
 
 func (c *Client) IsActive(ctx context.Context, ID uuid.UUID) (bool, error) {
-	return false, errors.New("not implemented")
+	return false, fmt.Errorf("Customer::Client::IsActive : %w: not implemented", ErrUnavailable)
+}
+
+// GetSegment returns the segment of the customer, e.g. enterprise.
+func (c *Client) GetSegment(ctx context.Context, ID uuid.UUID) (string, error) {
+	return "", fmt.Errorf("Customer::Client::GetSegment : %w: not implemented", ErrUnavailable)
 }
@@ -0,0 +1,272 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/google/uuid"
+)
+
+const (
+	LocationPath   string = "path"
+	LocationQuery  string = "query"
+	LocationHeader string = "header"
+	LocationBody   string = "body"
+)
+
+type (
+	// Violation is a single mismatch between a request or response and the contract.
+	Violation struct {
+		Location string `json:"location"`
+		Field    string `json:"field,omitempty"`
+		Message  string `json:"message"`
+	}
+
+	// Validator checks requests and responses against the OpenAPI document.
+	Validator struct {
+		spec       *openapi3.T
+		pathPrefix string
+		options    *openapi3filter.Options
+	}
+
+	operation struct {
+		route      *routers.Route
+		pathParams map[string]string
+	}
+)
+
+var (
+	ErrOperationNotFound = errors.New("operation not found")
+)
+
+// NewValidator loads the OpenAPI document. Request paths are matched after removing pathPrefix,
+// which is the path of the document server url.
+//
+// The document is not checked by openapi3.T.Validate, it only supports OpenAPI 3.0
+// and rejects the 3.1 null type used for optional objects.
+func NewValidator(ctx context.Context, data []byte, pathPrefix string) (*Validator, error) {
+	openapi3.DefineStringFormatCallback("uuid", func(value string) error {
+		_, err := uuid.Parse(value)
+		return err
+	})
+
+	loader := openapi3.NewLoader()
+	loader.Context = ctx
+
+	spec, err := loader.LoadFromData(data)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI::NewValidator : %w", err)
+	}
+
+	return &Validator{
+		spec:       spec,
+		pathPrefix: pathPrefix,
+		options: &openapi3filter.Options{
+			MultiError: true,
+			// authentication is done by the application middlewares
+			AuthenticationFunc:    openapi3filter.NoopAuthenticationFunc,
+			IncludeResponseStatus: true,
+		},
+	}, nil
+}
+
+// ValidateRequest returns every violation of the request. The request body stays readable.
+func (v *Validator) ValidateRequest(r *http.Request) ([]Violation, error) {
+	op, err := v.findOperation(r)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := readBody(&r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("OpenAPI::Validator::ValidateRequest : %w", err)
+	}
+	defer func() { r.Body = io.NopCloser(bytes.NewReader(body)) }()
+
+	err = openapi3filter.ValidateRequest(r.Context(), v.requestInput(r, op))
+
+	return violations(err), nil
+}
+
+// ValidateResponse returns every violation of the response written for the request.
+func (v *Validator) ValidateResponse(r *http.Request, status int, header http.Header, body []byte) ([]Violation, error) {
+	op, err := v.findOperation(r)
+	if err != nil {
+		return nil, err
+	}
+
+	err = openapi3filter.ValidateResponse(r.Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: v.requestInput(r, op),
+		Status:                 status,
+		Header:                 header,
+		Body:                   io.NopCloser(bytes.NewReader(body)),
+		Options:                v.options,
+	})
+
+	return violations(err), nil
+}
+
+func (v *Validator) requestInput(r *http.Request, op *operation) *openapi3filter.RequestValidationInput {
+	return &openapi3filter.RequestValidationInput{
+		Request:    r,
+		PathParams: op.pathParams,
+		Route:      op.route,
+		Options:    v.options,
+	}
+}
+
+// findOperation matches the request path against the path templates of the document,
+// preferring templates with more literal segments, so /revisions/diff wins over /revisions/{revision}.
+func (v *Validator) findOperation(r *http.Request) (*operation, error) {
+	path, found := strings.CutPrefix(r.URL.Path, v.pathPrefix)
+	if !found {
+		return nil, ErrOperationNotFound
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	var (
+		best         *operation
+		bestLiterals = -1
+	)
+	for template, pathItem := range v.spec.Paths.Map() {
+		pathParams, literals, ok := matchTemplate(strings.Split(strings.Trim(template, "/"), "/"), segments)
+		if !ok || literals <= bestLiterals {
+			continue
+		}
+
+		op := pathItem.GetOperation(r.Method)
+		if op == nil {
+			continue
+		}
+
+		best, bestLiterals = &operation{
+			route: &routers.Route{
+				Spec:      v.spec,
+				Path:      template,
+				PathItem:  pathItem,
+				Method:    r.Method,
+				Operation: op,
+			},
+			pathParams: pathParams,
+		}, literals
+	}
+
+	if best == nil {
+		return nil, ErrOperationNotFound
+	}
+
+	return best, nil
+}
+
+func matchTemplate(template []string, segments []string) (map[string]string, int, bool) {
+	if len(template) != len(segments) {
+		return nil, 0, false
+	}
+
+	pathParams := make(map[string]string)
+	literals := 0
+	for i, part := range template {
+		open, close := strings.Index(part, "{"), strings.LastIndex(part, "}")
+		if open < 0 || close < open {
+			if part != segments[i] {
+				return nil, 0, false
+			}
+			literals++
+			continue
+		}
+
+		// a parameter may share its segment with literal text, e.g. `{quoteID}.pdf`
+		prefix, suffix := part[:open], part[close+1:]
+		value, found := strings.CutPrefix(segments[i], prefix)
+		if !found {
+			return nil, 0, false
+		}
+		value, found = strings.CutSuffix(value, suffix)
+		if !found || value == "" {
+			return nil, 0, false
+		}
+		if prefix != "" || suffix != "" {
+			literals++
+		}
+		pathParams[part[open+1:close]] = value
+	}
+
+	return pathParams, literals, true
+}
+
+// violations flattens the validation errors into one violation per invalid field.
+func violations(err error) []Violation {
+	if err == nil {
+		return nil
+	}
+
+	// a request error unwraps to its schema errors, so only the list returned by the filter is split here
+	if multiError, ok := err.(openapi3.MultiError); ok {
+		result := make([]Violation, 0, len(multiError))
+		for _, e := range multiError {
+			result = append(result, violations(e)...)
+		}
+		return result
+	}
+
+	var requestError *openapi3filter.RequestError
+	if errors.As(err, &requestError) {
+		location, field := LocationBody, ""
+		if requestError.Parameter != nil {
+			location, field = requestError.Parameter.In, requestError.Parameter.Name
+		}
+
+		return fieldViolations(location, field, requestError.Err, requestError.Error())
+	}
+
+	var responseError *openapi3filter.ResponseError
+	if errors.As(err, &responseError) {
+		return fieldViolations(LocationBody, "", responseError.Err, responseError.Error())
+	}
+
+	return []Violation{{Location: LocationBody, Message: err.Error()}}
+}
+
+func fieldViolations(location string, field string, err error, message string) []Violation {
+	var multiError openapi3.MultiError
+	if errors.As(err, &multiError) {
+		result := make([]Violation, 0, len(multiError))
+		for _, e := range multiError {
+			result = append(result, fieldViolations(location, field, e, e.Error())...)
+		}
+		return result
+	}
+
+	var schemaError *openapi3.SchemaError
+	if errors.As(err, &schemaError) {
+		if pointer := schemaError.JSONPointer(); len(pointer) > 0 {
+			field = strings.Join(pointer, ".")
+		}
+		message = schemaError.Reason
+	}
+
+	return []Violation{{Location: location, Field: field, Message: message}}
+}
+
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, err
+	}
+	(*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+
+	return data, nil
+}
@@ -0,0 +1,81 @@
+package openapi_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"app"
+	"app/internal/openapi"
+)
+
+func TestValidatorValidateRequestPathParameters(t *testing.T) {
+	// arrange
+	validator, err := openapi.NewValidator(context.Background(), app.OpenAPISpec, "/v1")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		path       string
+		violations []openapi.Violation
+	}{
+		{
+			name: "literal segment wins over parameter",
+			path: "/v1/customers/6f1c2b2e-8a4a-4f6e-9b1a-2d3c4e5f6a7b/quote/revisions/diff?from=1&to=2",
+		},
+		{
+			name: "invalid path parameter",
+			path: "/v1/customers/6f1c2b2e-8a4a-4f6e-9b1a-2d3c4e5f6a7b/quote/revisions/first",
+			violations: []openapi.Violation{
+				{Location: openapi.LocationPath, Field: "revision"},
+			},
+		},
+		{
+			name: "parameter with suffix",
+			path: "/v1/customers/6f1c2b2e-8a4a-4f6e-9b1a-2d3c4e5f6a7b/quotes/7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d.pdf",
+		},
+		{
+			name: "invalid parameter with suffix",
+			path: "/v1/customers/6f1c2b2e-8a4a-4f6e-9b1a-2d3c4e5f6a7b/quotes/latest.pdf",
+			violations: []openapi.Violation{
+				{Location: openapi.LocationPath, Field: "quoteID"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tt.path, nil)
+			assert.NoError(t, err)
+
+			// act
+			violations, err := validator.ValidateRequest(req)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Len(t, violations, len(tt.violations))
+			for i, violation := range tt.violations {
+				assert.Equal(t, violation.Location, violations[i].Location)
+				assert.Equal(t, violation.Field, violations[i].Field)
+				assert.NotEmpty(t, violations[i].Message)
+			}
+		})
+	}
+}
+
+func TestValidatorOperationNotFound(t *testing.T) {
+	// arrange
+	validator, err := openapi.NewValidator(context.Background(), app.OpenAPISpec, "/v1")
+	assert.NoError(t, err)
+
+	req, err := http.NewRequest("GET", "/health", nil)
+	assert.NoError(t, err)
+
+	// act
+	_, err = validator.ValidateRequest(req)
+
+	// assert
+	assert.ErrorIs(t, err, openapi.ErrOperationNotFound)
+}
@@ -3,6 +3,7 @@ package order
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"app/internal/quote/types"
 )
@@ -10,10 +11,14 @@ import (
 type Client struct {
 }
 
+var (
+	ErrUnavailable = errors.New("order service is unavailable")
+)
+
 func NewClient() *Client {
 	return &Client{}
 }
 
 func (c *Client) Process(ctx context.Context, quote *types.Quote) error {
-	return errors.New("not implemeted")
+	return fmt.Errorf("Order::Client::Process : %w: not implemented", ErrUnavailable)
 }
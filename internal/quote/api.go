@@ -1,35 +1,147 @@
 package quote
 
 import (
+	"app"
+	"app/internal/agent"
+	"app/internal/auth"
 	"app/internal/catalog"
+	"app/internal/config"
+	"app/internal/customer"
+	"app/internal/openapi"
 	"app/internal/order"
+	"app/internal/quote/document"
 	"app/internal/quote/domain"
 	"app/internal/quote/handler"
-	"app/internal/quote/repository"
+	"app/internal/quote/share"
 	"app/internal/tax"
+	"context"
+	"fmt"
+	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RouterAPIInitializer() *chi.Mux {
+const APIVersionPrefix string = "/v1"
+
+const (
+	requestTimeout       = 60 * time.Second
+	defaultExportTimeout = 30 * time.Minute
+)
+
+type (
+	customerClient interface {
+		GetSegment(ctx context.Context, customerUUID uuid.UUID) (string, error)
+	}
+
+	agentAssignments interface {
+		IsAssigned(ctx context.Context, agentID uuid.UUID, customerID uuid.UUID) (bool, error)
+	}
+
+	// Dependencies are the stores of the quote API and the services it asks about customers and agents.
+	Dependencies struct {
+		Quotes      quoteRepository
+		Idempotency idempotencyStore
+		Templates   templateStore
+		Shares      shareStore
+		Customers   customerClient
+		Assignments agentAssignments
+	}
+)
+
+// NewDependencies selects the stores configured for the application. pool is the database of the stores kept in
+// PostgreSQL, see NewDatabasePool.
+func NewDependencies(storage config.Storage, pool *pgxpool.Pool) (Dependencies, error) {
+	quoteRepository, err := newQuoteRepository(storage, pool)
+	if err != nil {
+		return Dependencies{}, fmt.Errorf("NewDependencies : %w", err)
+	}
+	idempotencyStore, err := newIdempotencyStore(storage, pool)
+	if err != nil {
+		return Dependencies{}, fmt.Errorf("NewDependencies : %w", err)
+	}
+	templateStore, err := newTemplateStore(storage, pool)
+	if err != nil {
+		return Dependencies{}, fmt.Errorf("NewDependencies : %w", err)
+	}
+	shareStore, err := newShareStore(storage, pool)
+	if err != nil {
+		return Dependencies{}, fmt.Errorf("NewDependencies : %w", err)
+	}
+
+	return Dependencies{
+		Quotes:      quoteRepository,
+		Idempotency: idempotencyStore,
+		Templates:   templateStore,
+		Shares:      shareStore,
+		Customers:   customer.NewClient(),
+		Assignments: agent.NewClient(),
+	}, nil
+}
+
+// RouterAPIInitializer wires the quote API with the dependencies, see NewDependencies.
+func RouterAPIInitializer(cfg config.Config, deps Dependencies) *chi.Mux {
+	idempotency := handler.IdempotencyMiddleware(deps.Idempotency, cfg.Storage.IdempotencyTTL)
+	conditional := handler.ConditionalMiddleware(cfg.RequireIfMatch)
+
+	validator, err := openapi.NewValidator(context.Background(), app.OpenAPISpec, APIVersionPrefix)
+	if err != nil {
+		log.Printf("Failed to load the OpenAPI contract: %v", err)
+		return nil
+	}
+	contract := handler.ContractMiddleware(validator, cfg.ValidateResponses)
+	bodyLimit := handler.BodyLimitMiddleware(handler.MaxBodyBytes)
+
+	// the export of a quote set is streamed for longer than other requests may take, so the timeout is set per route
+	timeout := middleware.Timeout(requestTimeout)
+	exportTimeout := cfg.Export.Timeout
+	if exportTimeout == 0 {
+		exportTimeout = defaultExportTimeout
+	}
+
+	catalogClient := catalog.NewClient()
+	renderer, err := document.NewRenderer(cfg.Document, catalogClient)
+	if err != nil {
+		log.Printf("Failed to initialize the quote document renderer: %v", err)
+		return nil
+	}
+
 	quoteService := domain.NewQuote(
-		repository.NewDynamoQuote(),
-		catalog.NewClient(),
+		deps.Quotes,
+		catalogClient,
 		tax.NewClient(),
 		order.NewClient(),
+		deps.Customers,
+		cfg.Quote,
 	)
+	templateService := domain.NewTemplate(deps.Templates, catalogClient, cfg.Quote)
+	shareService := domain.NewShare(deps.Quotes, deps.Shares, share.NewSigner(cfg.Share), cfg.Quote)
 	apiHandler := handler.NewAPIHandler(quoteService)
+	revisionHandler := handler.NewRevisionHandler(quoteService)
+	historyHandler := handler.NewHistoryHandler(quoteService)
+	documentHandler := handler.NewDocumentHandler(quoteService, renderer)
+	exportHandler := handler.NewExportHandler(quoteService, cfg.Export)
+	templateHandler := handler.NewTemplateHandler(templateService, quoteService)
+	shareHandler := handler.NewShareHandler(shareService)
+	verifier := auth.NewVerifier(cfg.Auth)
+
+	trustedProxies, err := handler.ParseTrustedProxies(cfg.TrustedProxies)
+	if err != nil {
+		log.Printf("Failed to parse the trusted proxies: %v", err)
+		return nil
+	}
 
 	r := chi.NewRouter()
 
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
+	r.Use(handler.RealIPMiddleware(trustedProxies))
+	r.Use(handler.LoggerMiddleware(log.New(os.Stdout, "", log.LstdFlags)))
 	r.Use(middleware.Recoverer)
-	r.Use(middleware.Timeout(60 * time.Second))
 
 	r.Route("/health", func(r chi.Router) {
 		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
@@ -38,15 +150,148 @@ func RouterAPIInitializer() *chi.Mux {
 		})
 	})
 
-	// Quote Routes
-	r.Route("/customers/{customerID}", func(r chi.Router) {
-		r.Method("GET", "/quote", handler.BaseHandler(apiHandler.GetQuote()))
-		r.Method("POST", "/quote/products", handler.BaseHandler(apiHandler.AddProduct()))
-		r.Method("PUT", "/quote/products/{productID}", handler.BaseHandler(apiHandler.UpdateProduct()))
-		r.Method("DELETE", "/quote/products/{productID}", handler.BaseHandler(apiHandler.DeleteProduct()))
-		r.Method("POST", "/quote", handler.BaseHandler(apiHandler.Process()))
-		r.Method("PUT", "/quote", handler.BaseHandler(apiHandler.UpdateAddress()))
-		r.Method("PUT", "/quote", handler.BaseHandler(apiHandler.UpdatePayment()))
+	// API version prefix, it matches the server url of Openapi.yaml
+	r.Route(APIVersionPrefix, func(r chi.Router) {
+		// Quote Routes
+		r.Route("/customers/{customerID}", func(r chi.Router) {
+			r.Use(timeout)
+			r.Use(handler.AuthMiddleware(verifier))
+			r.Use(handler.CustomerAccessMiddleware())
+			r.Use(bodyLimit)
+			r.Use(contract)
+			r.Use(idempotency)
+			r.Use(conditional)
+
+			r.Method("GET", "/quote", handler.BaseHandler(apiHandler.GetQuote()))
+			r.Method("GET", "/quote.pdf", handler.BaseHandler(documentHandler.GetQuoteDocument()))
+			r.Method("GET", "/quotes", handler.BaseHandler(historyHandler.ListQuotes()))
+			r.Method("GET", "/quotes/{quoteID}", handler.BaseHandler(historyHandler.GetQuote()))
+			r.Method("GET", "/quotes/{quoteID}.pdf", handler.BaseHandler(documentHandler.GetQuoteDocumentByID()))
+			r.Method("GET", "/quotes/{quoteID}/export", handler.BaseHandler(exportHandler.ExportQuote()))
+			r.Method("GET", "/quotes/{quoteID}/shares", handler.BaseHandler(shareHandler.ListShares()))
+			r.Method("POST", "/quotes/{quoteID}/shares", handler.BaseHandler(shareHandler.CreateShare()))
+			r.Method("DELETE", "/quotes/{quoteID}/shares/{shareID}", handler.BaseHandler(shareHandler.RevokeShare()))
+			r.Method("GET", "/quotes/{quoteID}/shares/{shareID}/accesses", handler.BaseHandler(shareHandler.ListShareAccesses()))
+			r.Method("GET", "/quotes/numbers/{quoteNumber}", handler.BaseHandler(apiHandler.GetQuoteByNumber()))
+			r.Method("GET", "/quote/revisions", handler.BaseHandler(revisionHandler.ListRevisions()))
+			r.Method("GET", "/quote/revisions/diff", handler.BaseHandler(revisionHandler.DiffRevisions()))
+			r.Method("GET", "/quote/revisions/{revision}", handler.BaseHandler(revisionHandler.GetRevision()))
+			r.Method("POST", "/quote/products", handler.BaseHandler(apiHandler.AddProduct()))
+			r.Method("POST", "/quote/products/bulk", handler.BaseHandler(apiHandler.ApplyProductOperations()))
+			r.Method("POST", "/quote/products/import", handler.BaseHandler(apiHandler.ImportProducts()))
+			r.Method("PUT", "/quote/products/{productID}", handler.BaseHandler(apiHandler.UpdateProduct()))
+			r.Method("DELETE", "/quote/products/{productID}", handler.BaseHandler(apiHandler.DeleteProduct()))
+			r.Method("PUT", "/quote/address", handler.BaseHandler(apiHandler.UpdateAddress()))
+			r.Method("PUT", "/quote/payment", handler.BaseHandler(apiHandler.UpdatePayment()))
+			r.Method("POST", "/quote/accept", handler.BaseHandler(apiHandler.Accept()))
+			r.Method("GET", "/quote/offers", handler.BaseHandler(apiHandler.ListOffers()))
+			r.Method("POST", "/quote/offers", handler.BaseHandler(apiHandler.ProposeOffer()))
+			r.Method("POST", "/quote/offers/{offerNumber}/accept", handler.BaseHandler(apiHandler.AcceptOffer()))
+			r.Method("POST", "/quote/process", handler.BaseHandler(apiHandler.Process()))
+			r.Method("POST", "/quote/reorder", handler.BaseHandler(apiHandler.Reorder()))
+			r.Method("POST", "/quote/templates/{templateID}", handler.BaseHandler(templateHandler.ApplyTemplate()))
+			r.Method("PUT", "/quote/name", handler.BaseHandler(apiHandler.RenameDraft()))
+			r.Method("GET", "/drafts", handler.BaseHandler(apiHandler.ListDrafts()))
+			r.Method("POST", "/drafts", handler.BaseHandler(apiHandler.CreateDraft()))
+
+			// the same operations on a draft by its ID, whichever draft is active
+			r.Group(func(r chi.Router) {
+				r.Use(handler.DraftScopeMiddleware())
+
+				r.Method("POST", "/quotes/{quoteID}/products", handler.BaseHandler(apiHandler.AddProduct()))
+				r.Method("POST", "/quotes/{quoteID}/products/bulk", handler.BaseHandler(apiHandler.ApplyProductOperations()))
+				r.Method("POST", "/quotes/{quoteID}/products/import", handler.BaseHandler(apiHandler.ImportProducts()))
+				r.Method("PUT", "/quotes/{quoteID}/products/{productID}", handler.BaseHandler(apiHandler.UpdateProduct()))
+				r.Method("DELETE", "/quotes/{quoteID}/products/{productID}", handler.BaseHandler(apiHandler.DeleteProduct()))
+				r.Method("PUT", "/quotes/{quoteID}/address", handler.BaseHandler(apiHandler.UpdateAddress()))
+				r.Method("PUT", "/quotes/{quoteID}/payment", handler.BaseHandler(apiHandler.UpdatePayment()))
+				r.Method("POST", "/quotes/{quoteID}/accept", handler.BaseHandler(apiHandler.Accept()))
+				r.Method("GET", "/quotes/{quoteID}/offers", handler.BaseHandler(apiHandler.ListOffers()))
+				r.Method("POST", "/quotes/{quoteID}/offers", handler.BaseHandler(apiHandler.ProposeOffer()))
+				r.Method("POST", "/quotes/{quoteID}/offers/{offerNumber}/accept", handler.BaseHandler(apiHandler.AcceptOffer()))
+				r.Method("POST", "/quotes/{quoteID}/process", handler.BaseHandler(apiHandler.Process()))
+				r.Method("POST", "/quotes/{quoteID}/reorder", handler.BaseHandler(apiHandler.Reorder()))
+				r.Method("POST", "/quotes/{quoteID}/templates/{templateID}", handler.BaseHandler(templateHandler.ApplyTemplate()))
+				r.Method("PUT", "/quotes/{quoteID}/name", handler.BaseHandler(apiHandler.RenameDraft()))
+				r.Method("POST", "/quotes/{quoteID}/activate", handler.BaseHandler(apiHandler.ActivateDraft()))
+			})
+		})
+
+		// Staff Export Routes
+		r.Route("/exports", func(r chi.Router) {
+			r.Use(middleware.Timeout(exportTimeout))
+			r.Use(handler.AuthMiddleware(verifier))
+			r.Use(handler.StaffAccessMiddleware())
+			r.Use(contract)
+
+			r.Method("GET", "/quotes", handler.BaseHandler(exportHandler.ExportQuotes()))
+		})
+
+		// Shared Quote Routes, the signed token of the link is the only credential and nothing can be changed
+		r.Route("/shared", func(r chi.Router) {
+			r.Use(timeout)
+			r.Use(contract)
+
+			r.Method("GET", "/quotes/{token}", handler.BaseHandler(shareHandler.GetSharedQuote()))
+		})
+
+		// Quote Template Routes, readable with any token and managed by staff
+		r.Group(func(r chi.Router) {
+			r.Use(timeout)
+			r.Use(handler.AuthMiddleware(verifier))
+			r.Use(bodyLimit)
+
+			staff := handler.StaffAccessMiddleware()
+
+			r.With(contract).Method("GET", "/templates", handler.BaseHandler(templateHandler.ListTemplates()))
+			r.With(contract).Method("GET", "/templates/{templateID}", handler.BaseHandler(templateHandler.GetTemplate()))
+			r.With(staff, contract).Method("POST", "/templates", handler.BaseHandler(templateHandler.CreateTemplate()))
+			r.With(staff, contract).Method("PUT", "/templates/{templateID}", handler.BaseHandler(templateHandler.UpdateTemplate()))
+			r.With(staff, contract).Method("DELETE", "/templates/{templateID}", handler.BaseHandler(templateHandler.DeleteTemplate()))
+		})
+
+		// Sales Agent Quote Routes
+		r.Route("/agents/{agentID}/customers/{customerID}", func(r chi.Router) {
+			r.Use(timeout)
+			r.Use(handler.AuthMiddleware(verifier))
+			r.Use(handler.AgentAccessMiddleware(deps.Assignments))
+			r.Use(bodyLimit)
+			r.Use(idempotency)
+			r.Use(conditional)
+
+			view := handler.PermissionMiddleware(auth.PermissionQuoteView)
+			edit := handler.PermissionMiddleware(auth.PermissionQuoteEdit)
+			discount := handler.PermissionMiddleware(auth.PermissionQuoteDiscount)
+			submit := handler.PermissionMiddleware(auth.PermissionQuoteSubmit)
+			override := handler.PermissionMiddleware(auth.PermissionQuoteOverride)
+			approve := handler.PermissionMiddleware(auth.PermissionQuoteApprove)
+			negotiate := handler.PermissionMiddleware(auth.PermissionQuoteNegotiate)
+
+			// the contract is checked after the permission, so a forbidden request does not learn the schema
+
+			r.With(view, contract).Method("GET", "/quote", handler.BaseHandler(apiHandler.GetQuote()))
+			r.With(view, contract).Method("GET", "/quote.pdf", handler.BaseHandler(documentHandler.GetQuoteDocument()))
+			r.With(view, contract).Method("GET", "/quotes/numbers/{quoteNumber}", handler.BaseHandler(apiHandler.GetQuoteByNumber()))
+			r.With(edit, contract).Method("POST", "/quote/products", handler.BaseHandler(apiHandler.AddProduct()))
+			r.With(edit, contract).Method("POST", "/quote/products/bulk", handler.BaseHandler(apiHandler.ApplyProductOperations()))
+			r.With(edit, contract).Method("POST", "/quote/products/import", handler.BaseHandler(apiHandler.ImportProducts()))
+			r.With(edit, contract).Method("PUT", "/quote/products/{productID}", handler.BaseHandler(apiHandler.UpdateProduct()))
+			r.With(edit, contract).Method("DELETE", "/quote/products/{productID}", handler.BaseHandler(apiHandler.DeleteProduct()))
+			r.With(discount, contract).Method("PUT", "/quote/products/{productID}/discount", handler.BaseHandler(apiHandler.ApplyDiscount()))
+			r.With(override, contract).Method("PUT", "/quote/products/{productID}/price", handler.BaseHandler(apiHandler.OverridePrice()))
+			r.With(approve, contract).Method("POST", "/quote/products/{productID}/price/approve", handler.BaseHandler(apiHandler.ApprovePriceOverride()))
+			r.With(approve, contract).Method("POST", "/quote/products/{productID}/price/reject", handler.BaseHandler(apiHandler.RejectPriceOverride()))
+			r.With(edit, contract).Method("PUT", "/quote/address", handler.BaseHandler(apiHandler.UpdateAddress()))
+			r.With(edit, contract).Method("PUT", "/quote/payment", handler.BaseHandler(apiHandler.UpdatePayment()))
+			r.With(submit, contract).Method("POST", "/quote/process", handler.BaseHandler(apiHandler.Process()))
+			// an accepted quote is no longer the active draft, so the agent processes it by its ID
+			r.With(submit, contract, handler.DraftScopeMiddleware()).Method("POST", "/quotes/{quoteID}/process", handler.BaseHandler(apiHandler.Process()))
+			r.With(edit, contract).Method("POST", "/quote/reorder", handler.BaseHandler(apiHandler.Reorder()))
+			r.With(edit, contract).Method("POST", "/quote/templates/{templateID}", handler.BaseHandler(templateHandler.ApplyTemplate()))
+			r.With(view, contract).Method("GET", "/quote/offers", handler.BaseHandler(apiHandler.ListOffers()))
+			r.With(negotiate, contract).Method("POST", "/quote/offers", handler.BaseHandler(apiHandler.ProposeOffer()))
+			r.With(negotiate, contract).Method("POST", "/quote/offers/{offerNumber}/accept", handler.BaseHandler(apiHandler.AcceptOffer()))
+		})
 	})
 
 	return r
@@ -1,62 +1,128 @@
 package quote_test
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"log"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 
-	"app/internal/catalog"
-	"app/internal/customer"
-	"app/internal/order"
+	"app/internal/auth"
+	"app/internal/config"
+	"app/internal/openapi"
+	"app/internal/quote"
+	"app/internal/quote/document"
 	"app/internal/quote/domain"
+	"app/internal/quote/export"
 	"app/internal/quote/handler"
 	"app/internal/quote/repository"
-	"app/internal/tax"
+	"app/internal/quote/share"
+	"app/internal/quote/types"
 )
 
-type testApiHandle struct {
-	handler         *handler.APIHandler
-	customerService *customer.Client
+const (
+	testAuthKey = "test-secret"
+	// testTrustedProxy is the range of the proxies whose forwarded client address the API takes.
+	testTrustedProxy = "10.0.0.0/8"
+)
+
+type (
+	testApiHandle struct {
+		quotes         *repository.MemoryQuote
+		templates      *repository.MemoryTemplate
+		idempotency    *repository.MemoryIdempotency
+		shares         *repository.MemoryShare
+		assignments    *testAgentAssignments
+		requireIfMatch bool
+	}
+
+	testCustomerService struct{}
+
+	testAgentAssignments struct {
+		customers map[uuid.UUID]uuid.UUID
+	}
+)
+
+func (s *testCustomerService) GetSegment(ctx context.Context, customerUUID uuid.UUID) (string, error) {
+	return "enterprise", nil
 }
 
-func newTestApiHandler() *testApiHandle {
-	quoteService := domain.NewQuote(
-		repository.NewDynamoQuote(),
-		catalog.NewClient(),
-		tax.NewClient(),
-		order.NewClient(),
-	)
+func (a *testAgentAssignments) IsAssigned(ctx context.Context, agentID uuid.UUID, customerID uuid.UUID) (bool, error) {
+	return a.customers[customerID] == agentID, nil
+}
 
+func newTestApiHandler(t *testing.T) *testApiHandle {
 	return &testApiHandle{
-		handler: handler.NewAPIHandler(quoteService),
+		quotes:      repository.NewMemoryQuote(),
+		templates:   repository.NewMemoryTemplate(),
+		idempotency: repository.NewMemoryIdempotency(),
+		shares:      repository.NewMemoryShare(),
+		assignments: &testAgentAssignments{customers: make(map[uuid.UUID]uuid.UUID)},
 	}
 }
 
+// router serves the API as the application does, with the stores of the test and the catalog, tax and order
+// services unavailable.
+func (tc *testApiHandle) router() *chi.Mux {
+	return quote.RouterAPIInitializer(config.Config{
+		Auth:     auth.Config{StaticKey: testAuthKey},
+		Quote:    domain.Config{AcceptanceSegments: []string{"enterprise"}},
+		Document: document.Config{CompanyName: "Meisterwerk", Currency: "EUR"},
+		Export:   export.Config{SellerName: "Meisterwerk", Currency: "EUR"},
+		Share:    share.Config{Key: testAuthKey},
+		Storage:  config.Storage{IdempotencyTTL: time.Hour},
+		// every response of the tests is checked against the contract
+		ValidateResponses: true,
+		RequireIfMatch:    tc.requireIfMatch,
+		TrustedProxies:    []string{testTrustedProxy},
+	}, quote.Dependencies{
+		Quotes:      tc.quotes,
+		Idempotency: tc.idempotency,
+		Templates:   tc.templates,
+		Shares:      tc.shares,
+		Customers:   &testCustomerService{},
+		Assignments: tc.assignments,
+	})
+}
+
+func newTestToken(t *testing.T, subject string, roles ...string) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":   subject,
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testAuthKey))
+	assert.NoError(t, err)
+
+	return token
+}
+
 // api test example...
 // check contract here, also it can be used as integration test...
 func TestApiHandlerGetQuoteNewQuote(t *testing.T) {
 	// arrange
 	customerUUID := uuid.NewString()
-	tc := newTestApiHandler()
+	tc := newTestApiHandler(t)
 
 	rec := httptest.NewRecorder()
-	req, err := http.NewRequest("GET", fmt.Sprintf("/customers/%s/quote", customerUUID), nil)
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote", customerUUID), nil)
 	assert.NoError(t, err)
-
-	r := chi.NewRouter()
-	r.Use(handler.CustomerCtxMiddleware(tc.customerService))
-	r.Method("GET", "/customers/{customerID}/quote", handler.BaseHandler(tc.handler.GetQuote()))
-
-	fmt.Println(r.Routes())
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
 
 	// act
-	r.ServeHTTP(rec, req)
+	tc.router().ServeHTTP(rec, req)
 
 	// assert
 	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
@@ -65,9 +131,1541 @@ func TestApiHandlerGetQuoteNewQuote(t *testing.T) {
 	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &quote))
 
 	expectedQuote := map[string]interface{}{
-		"amount":     0,
-		"tax_amount": 0,
+		"amount":     0.0,
+		"tax_amount": 0.0,
+	}
+	for field, value := range expectedQuote {
+		assert.Equal(t, value, quote[field], field)
+	}
+
+	assert.Equal(t, 0.0, quote["total_amount"])
+	assert.Equal(t, []interface{}{}, quote["products"])
+	assert.Nil(t, quote["address"])
+	assert.Nil(t, quote["payment"])
+}
+
+func TestApiHandlerGetQuoteWithoutToken(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote", uuid.NewString()), nil)
+	assert.NoError(t, err)
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusUnauthorized, rec.Result().StatusCode)
+}
+
+func TestApiHandlerGetQuoteOtherCustomer(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote", uuid.NewString()), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, uuid.NewString()))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+}
+
+func TestApiHandlerGetQuoteStaff(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote", uuid.NewString()), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, uuid.NewString(), auth.RoleStaff))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+}
+
+func TestApiHandlerAgentGetQuoteAssignedCustomer(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	agentUUID, customerUUID := uuid.New(), uuid.New()
+	tc.assignments.customers[customerUUID] = agentUUID
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/agents/%s/customers/%s/quote", agentUUID, customerUUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, agentUUID.String(), auth.RoleSalesAgent))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+}
+
+func TestApiHandlerAgentGetQuoteNotAssignedCustomer(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	agentUUID, customerUUID := uuid.New(), uuid.New()
+	tc.assignments.customers[customerUUID] = uuid.New()
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/agents/%s/customers/%s/quote", agentUUID, customerUUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, agentUUID.String(), auth.RoleSalesAgent))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+
+	problem := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "access to the customer is forbidden", problem["detail"])
+}
+
+func TestApiHandlerAgentDiscountWithoutPermission(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	agentUUID, customerUUID := uuid.New(), uuid.New()
+	tc.assignments.customers[customerUUID] = agentUUID
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest(
+		"PUT",
+		fmt.Sprintf("/v1/agents/%s/customers/%s/quote/products/%s/discount", agentUUID, customerUUID, uuid.New()),
+		strings.NewReader(`{"discount_percent": 10}`),
+	)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, agentUUID.String(), auth.RoleSalesAgent))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+
+	problem := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "roles of the token do not grant the operation", problem["detail"])
+}
+
+func TestApiHandlerListRevisions(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.NewString()
+	token := newTestToken(t, customerUUID)
+	r := tc.router()
+
+	for _, city := range []string{"Berlin", "Hamburg"} {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(
+			"PUT",
+			fmt.Sprintf("/v1/customers/%s/quote/address", customerUUID),
+			strings.NewReader(fmt.Sprintf(`{"address": "Main St. 1", "city": "%s", "country": "DE"}`, city)),
+		)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		r.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	}
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote/revisions", customerUUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// act
+	r.ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	var revisions []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revisions))
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 2.0, revisions[1]["number"])
+	assert.Equal(t, map[string]interface{}{"type": "customer", "id": customerUUID}, revisions[1]["created_by"])
+}
+
+func TestApiHandlerGetRevisionNotFound(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.NewString()
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote/revisions/7", customerUUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, rec.Result().StatusCode)
+}
+
+func TestApiHandlerAddProductContractViolations(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/products", customerUUID),
+		strings.NewReader(`{"product_id": "not-a-uuid", "qty": 0}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+
+	var response struct {
+		Errors []openapi.Violation `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	fields := make([]string, 0, len(response.Errors))
+	for _, violation := range response.Errors {
+		assert.Equal(t, openapi.LocationBody, violation.Location)
+		fields = append(fields, violation.Field)
+	}
+	assert.ElementsMatch(t, []string{"product_id", "qty"}, fields)
+}
+
+func TestApiHandlerAddProductMissingFields(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/products", customerUUID),
+		strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusBadRequest, rec.Result().StatusCode)
+
+	var response struct {
+		Errors []openapi.Violation `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+
+	fields := make([]string, 0, len(response.Errors))
+	for _, violation := range response.Errors {
+		fields = append(fields, violation.Field)
+	}
+	assert.ElementsMatch(t, []string{"product_id", "qty"}, fields)
+}
+
+func TestApiHandlerUpdateAddressInvalidCountry(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/v1/customers/%s/quote/address", customerUUID),
+		strings.NewReader(`{"address": "Main St. 1", "city": "Berlin", "country": "Germany"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.RequestIDHeader, "test-request-1")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Result().StatusCode)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, fmt.Sprintf(`{
+		"type": "urn:quote-api:problem:validation-failed",
+		"title": "Validation Failed",
+		"status": 422,
+		"detail": "quote input is invalid",
+		"instance": "/v1/customers/%s/quote/address",
+		"request_id": "test-request-1",
+		"errors": [{"location": "body", "field": "country", "message": "country must be an ISO 3166-1 alpha-2 code"}]
+	}`, customerUUID), rec.Body.String())
+}
+
+func TestApiHandlerUpdateProductNotInQuote(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/v1/customers/%s/quote/products/%s", customerUUID, uuid.New()),
+		strings.NewReader(`{"qty": 1}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusNotFound, rec.Result().StatusCode)
+
+	problem := make(map[string]interface{})
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Equal(t, "urn:quote-api:problem:product-not-found", problem["type"])
+	assert.Equal(t, 404.0, problem["status"])
+	assert.NotEmpty(t, problem["request_id"])
+}
+
+func TestApiHandlerIdempotencyKey(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.NewString()
+	token := newTestToken(t, customerUUID)
+	r := tc.router()
+
+	send := func(method string, path string, body string, key string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(method, fmt.Sprintf("/v1/customers/%s%s", customerUUID, path), strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(handler.IdempotencyKeyHeader, key)
+		r.ServeHTTP(rec, req)
+
+		return rec
+	}
+	address := `{"address": "Main St. 1", "city": "Berlin", "country": "DE"}`
+
+	// act
+	first := send("PUT", "/quote/address", address, "address-1")
+	retry := send("PUT", "/quote/address", address, "address-1")
+	reused := send("PUT", "/quote/address", `{"address": "Main St. 2", "city": "Berlin", "country": "DE"}`, "address-1")
+	failed := send("POST", "/quote/products", `{"product_id": "`+uuid.NewString()+`", "qty": 1}`, "product-1")
+	failedRetry := send("POST", "/quote/products", `{"product_id": "`+uuid.NewString()+`", "qty": 1}`, "product-1")
+
+	// assert
+	assert.Equal(t, http.StatusOK, first.Result().StatusCode)
+	assert.Empty(t, first.Header().Get(handler.IdempotentReplayedHeader))
+
+	assert.Equal(t, http.StatusOK, retry.Result().StatusCode)
+	assert.Equal(t, "true", retry.Header().Get(handler.IdempotentReplayedHeader))
+	assert.JSONEq(t, first.Body.String(), retry.Body.String())
+
+	assert.Equal(t, http.StatusUnprocessableEntity, reused.Result().StatusCode)
+	assert.Contains(t, reused.Body.String(), "urn:quote-api:problem:idempotency-key-reused")
+
+	// the catalog is not available, server errors release the key
+	assert.Equal(t, http.StatusServiceUnavailable, failed.Result().StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, failedRetry.Result().StatusCode)
+	assert.Empty(t, failedRetry.Header().Get(handler.IdempotentReplayedHeader))
+
+	revisions := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote/revisions", customerUUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+token)
+	r.ServeHTTP(revisions, req)
+	assert.Equal(t, http.StatusOK, revisions.Result().StatusCode)
+
+	var list []map[string]interface{}
+	assert.NoError(t, json.Unmarshal(revisions.Body.Bytes(), &list))
+	assert.Len(t, list, 1)
+}
+
+func TestApiHandlerIdempotencyKeyPanic(t *testing.T) {
+	// arrange
+	calls := 0
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			panic(http.ErrAbortHandler)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	h := handler.IdempotencyMiddleware(repository.NewMemoryIdempotency(), time.Hour)(next)
+
+	send := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", "/quote/process", strings.NewReader(`{}`))
+		assert.NoError(t, err)
+		req.Header.Set(handler.IdempotencyKeyHeader, "process-1")
+		h.ServeHTTP(rec, req)
+
+		return rec
+	}
+
+	// act
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() { send() })
+	retry := send()
+
+	// assert
+	assert.Equal(t, http.StatusNoContent, retry.Result().StatusCode)
+	assert.Empty(t, retry.Header().Get(handler.IdempotentReplayedHeader))
+	assert.Equal(t, 2, calls)
+}
+
+func TestApiHandlerConditionalRequests(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.NewString()
+	token := newTestToken(t, customerUUID)
+	r := tc.router()
+
+	send := func(method string, path string, body string, header string, etag string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(method, fmt.Sprintf("/v1/customers/%s%s", customerUUID, path), strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			req.Header.Set(header, etag)
+		}
+		r.ServeHTTP(rec, req)
+
+		return rec
+	}
+	address := `{"address": "Main St. 1", "city": "Berlin", "country": "DE"}`
+
+	// act
+	read := send("GET", "/quote", "", "", "")
+	notModified := send("GET", "/quote", "", "If-None-Match", read.Header().Get("ETag"))
+	changed := send("PUT", "/quote/address", address, "If-Match", read.Header().Get("ETag"))
+	stale := send("PUT", "/quote/address", address, "If-Match", read.Header().Get("ETag"))
+	modified := send("GET", "/quote", "", "If-None-Match", read.Header().Get("ETag"))
+
+	// assert
+	assert.Equal(t, http.StatusOK, read.Result().StatusCode)
+	assert.NotEmpty(t, read.Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusNotModified, notModified.Result().StatusCode)
+	assert.Empty(t, notModified.Body.String())
+
+	assert.Equal(t, http.StatusOK, changed.Result().StatusCode)
+	assert.NotEqual(t, read.Header().Get("ETag"), changed.Header().Get("ETag"))
+
+	assert.Equal(t, http.StatusPreconditionFailed, stale.Result().StatusCode)
+	assert.Contains(t, stale.Body.String(), "urn:quote-api:problem:quote-modified")
+
+	assert.Equal(t, http.StatusOK, modified.Result().StatusCode)
+	assert.Equal(t, changed.Header().Get("ETag"), modified.Header().Get("ETag"))
+}
+
+func TestApiHandlerIfMatchRequired(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	tc.requireIfMatch = true
+	customerUUID := uuid.NewString()
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/v1/customers/%s/quote/address", customerUUID),
+		strings.NewReader(`{"address": "Main St. 1", "city": "Berlin", "country": "DE"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusPreconditionRequired, rec.Result().StatusCode)
+}
+
+func TestApiHandlerApplyProductOperationsInvalid(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/products/bulk", customerUUID),
+		strings.NewReader(fmt.Sprintf(`{"operations": [
+			{"op": "remove", "product_id": "%s"},
+			{"op": "update", "product_id": "%s", "qty": 2}
+		]}`, uuid.New(), uuid.New())))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Result().StatusCode)
+
+	var problem struct {
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Len(t, problem.Errors, 2)
+	assert.Equal(t, "operations.0.product_id", problem.Errors[0].Field)
+	assert.Equal(t, "operations.1.product_id", problem.Errors[1].Field)
+}
+
+func newTestImportRequest(t *testing.T, customerUUID string, filename string, content string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write([]byte(content))
+	assert.NoError(t, err)
+	assert.NoError(t, form.Close())
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/products/import", customerUUID), &body)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", form.FormDataContentType())
+
+	return req
+}
+
+func TestApiHandlerImportProductsInvalidRows(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req := newTestImportRequest(t, customerUUID, "order.csv", fmt.Sprintf(
+		"product_id,sku,qty\n%s,,2\n,,1\nnot-a-uuid,,1\n%s,,many\n", uuid.New(), uuid.New()))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Result().StatusCode)
+
+	var problem struct {
+		Errors []struct {
+			Field string `json:"field"`
+		} `json:"errors"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &problem))
+	assert.Len(t, problem.Errors, 3)
+	assert.Equal(t, "rows.3.product_id", problem.Errors[0].Field)
+	assert.Equal(t, "rows.4.product_id", problem.Errors[1].Field)
+	assert.Equal(t, "rows.5.qty", problem.Errors[2].Field)
+}
+
+func TestApiHandlerImportProductsTooLarge(t *testing.T) {
+	content := "sku,qty\n" + strings.Repeat("BOLT-M8,1\n", int(handler.MaxBodyBytes)/10)
+
+	tests := []struct {
+		name    string
+		prepare func(req *http.Request)
+	}{
+		{name: "declared length", prepare: func(req *http.Request) {}},
+		{name: "streamed", prepare: func(req *http.Request) {
+			req.ContentLength = -1
+			req.Body = io.NopCloser(io.MultiReader(req.Body))
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			customerUUID := uuid.NewString()
+			tc := newTestApiHandler(t)
+
+			rec := httptest.NewRecorder()
+			req := newTestImportRequest(t, customerUUID, "order.csv", content)
+			req.Header.Set(handler.IdempotencyKeyHeader, "import-too-large")
+			tt.prepare(req)
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Result().StatusCode)
+			assert.Contains(t, rec.Body.String(), "urn:quote-api:problem:body-too-large")
+		})
+	}
+}
+
+func TestApiHandlerImportProductsUnsupportedFormat(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req := newTestImportRequest(t, customerUUID, "order.txt", "product_id,qty\n")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusUnsupportedMediaType, rec.Result().StatusCode)
+	assert.Contains(t, rec.Body.String(), "urn:quote-api:problem:unsupported-import-format")
+}
+
+func TestApiHandlerGetQuoteDocument(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quote.pdf", customerUUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+	assert.Equal(t, `"new.pdf"`, rec.Header().Get("ETag"))
+	assert.True(t, strings.HasPrefix(rec.Body.String(), "%PDF-"))
+}
+
+func TestApiHandlerGetQuoteDocumentByID(t *testing.T) {
+	// arrange
+	customerUUID := uuid.New()
+	tc := newTestApiHandler(t)
+
+	processed := types.NewQuote(uuid.New(), customerUUID)
+	processed.Revision = 1
+	processed.Status = types.QuoteStatusDone
+	assert.NoError(t, tc.quotes.Save(context.Background(), processed))
+
+	tests := []struct {
+		name     string
+		customer uuid.UUID
+		status   int
+	}{
+		{name: "own quote", customer: customerUUID, status: http.StatusOK},
+		{name: "quote of another customer", customer: uuid.New(), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quotes/%s.pdf", tt.customer, processed.UUID), nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, tt.customer.String()))
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tt.status, rec.Result().StatusCode)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, fmt.Sprintf(`"%s.%d.pdf"`, processed.UUID, processed.Version), rec.Header().Get("ETag"))
+				assert.NotEqual(t, processed.ETag(), rec.Header().Get("ETag"))
+			}
+		})
+	}
+}
+
+func TestApiHandlerGetQuoteByNumber(t *testing.T) {
+	// arrange
+	customerUUID := uuid.NewString()
+	tc := newTestApiHandler(t)
+	r := tc.router()
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("PUT", fmt.Sprintf("/v1/customers/%s/quote/address", customerUUID),
+		strings.NewReader(`{"address": "Main St. 1", "city": "Berlin", "country": "DE"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+
+	var saved struct {
+		ID     uuid.UUID `json:"id"`
+		Number string    `json:"number"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &saved))
+	assert.Equal(t, fmt.Sprintf("Q-%d-000001", time.Now().Year()), saved.Number)
+
+	tests := []struct {
+		name     string
+		customer string
+		number   string
+		status   int
+	}{
+		{name: "own quote", customer: customerUUID, number: saved.Number, status: http.StatusOK},
+		{name: "quote of another customer", customer: uuid.NewString(), number: saved.Number, status: http.StatusNotFound},
+		{name: "unknown number", customer: customerUUID, number: "Q-1999-000001", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quotes/numbers/%s", tt.customer, tt.number), nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, tt.customer))
+
+			// act
+			r.ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tt.status, rec.Result().StatusCode)
+			if tt.status == http.StatusOK {
+				assert.Contains(t, rec.Body.String(), saved.ID.String())
+			}
+		})
+	}
+}
+
+func TestApiHandlerListQuotes(t *testing.T) {
+	// arrange
+	customerUUID := uuid.New()
+	tc := newTestApiHandler(t)
+	r := tc.router()
+
+	quotes := make([]uuid.UUID, 0)
+	for i, status := range []types.QuoteStatus{types.QuoteStatusDone, types.QuoteStatusDone, types.QuoteStatusDraft} {
+		quote := types.NewQuote(uuid.New(), customerUUID)
+		quote.Revision = 1
+		quote.Status = status
+		quote.UpdatedAt = time.Date(2026, 1, 1+i, 0, 0, 0, 0, time.UTC)
+		assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+		quotes = append(quotes, quote.UUID)
 	}
+	other := types.NewQuote(uuid.New(), uuid.New())
+	other.Revision = 1
+	assert.NoError(t, tc.quotes.Save(context.Background(), other))
+
+	list := func(t *testing.T, query string) (int, []uuid.UUID, *string) {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quotes%s", customerUUID, query), nil)
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID.String()))
+		r.ServeHTTP(rec, req)
+
+		var response struct {
+			Quotes []struct {
+				ID uuid.UUID `json:"id"`
+			} `json:"quotes"`
+			NextCursor *string `json:"next_cursor"`
+		}
+		ids := make([]uuid.UUID, 0)
+		if rec.Result().StatusCode == http.StatusOK {
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			for _, quote := range response.Quotes {
+				ids = append(ids, quote.ID)
+			}
+		}
+
+		return rec.Result().StatusCode, ids, response.NextCursor
+	}
+
+	tests := []struct {
+		name     string
+		query    string
+		status   int
+		expected []uuid.UUID
+	}{
+		{name: "latest first", query: "", status: http.StatusOK, expected: []uuid.UUID{quotes[2], quotes[1], quotes[0]}},
+		{name: "oldest first", query: "?sort=updated_at", status: http.StatusOK, expected: quotes},
+		{name: "by status", query: "?status=done", status: http.StatusOK, expected: []uuid.UUID{quotes[1], quotes[0]}},
+		{name: "by update", query: "?updated_from=2026-01-02T00:00:00Z&updated_to=2026-01-03T00:00:00Z", status: http.StatusOK, expected: []uuid.UUID{quotes[1]}},
+		{name: "invalid cursor", query: "?cursor=x", status: http.StatusBadRequest, expected: []uuid.UUID{}},
+		{name: "invalid sort", query: "?sort=total_amount", status: http.StatusBadRequest, expected: []uuid.UUID{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			status, ids, _ := list(t, tt.query)
+
+			// assert
+			assert.Equal(t, tt.status, status)
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		// act
+		status, firstPage, next := list(t, "?limit=2")
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotNil(t, next)
+		status, lastPage, last := list(t, "?limit=2&cursor="+*next)
+
+		// assert
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, []uuid.UUID{quotes[2], quotes[1]}, firstPage)
+		assert.Equal(t, []uuid.UUID{quotes[0]}, lastPage)
+		assert.Nil(t, last)
+	})
+}
+
+func TestApiHandlerGetQuoteByID(t *testing.T) {
+	// arrange
+	customerUUID := uuid.New()
+	tc := newTestApiHandler(t)
+
+	processed := types.NewQuote(uuid.New(), customerUUID)
+	processed.Revision = 1
+	processed.Status = types.QuoteStatusDone
+	assert.NoError(t, tc.quotes.Save(context.Background(), processed))
+
+	tests := []struct {
+		name     string
+		customer uuid.UUID
+		status   int
+	}{
+		{name: "own quote", customer: customerUUID, status: http.StatusOK},
+		{name: "quote of another customer", customer: uuid.New(), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quotes/%s", tt.customer, processed.UUID), nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, tt.customer.String()))
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tt.status, rec.Result().StatusCode)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, processed.ETag(), rec.Header().Get("ETag"))
+				assert.Contains(t, rec.Body.String(), `"status":"done"`)
+			}
+		})
+	}
+}
+
+func TestApiHandlerDrafts(t *testing.T) {
+	// arrange
+	customerUUID := uuid.New()
+	tc := newTestApiHandler(t)
+	r := tc.router()
+
+	do := func(t *testing.T, method string, path string, body string) (int, map[string]interface{}) {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(method, fmt.Sprintf("/v1/customers/%s%s", customerUUID, path), strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID.String()))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		r.ServeHTTP(rec, req)
+
+		response := make(map[string]interface{})
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		return rec.Result().StatusCode, response
+	}
+
+	status, first := do(t, "PUT", "/quote/address", `{"address": "Main St. 1", "city": "Berlin", "country": "DE"}`)
+	assert.Equal(t, http.StatusOK, status)
+
+	processed := types.NewQuote(uuid.New(), customerUUID)
+	processed.Revision = 1
+	processed.Status = types.QuoteStatusDone
+	assert.NoError(t, tc.quotes.Save(context.Background(), processed))
+
+	t.Run("create", func(t *testing.T) {
+		// act
+		status, created := do(t, "POST", "/drafts", `{"name": " Kitchen "}`)
+		_, active := do(t, "GET", "/quote", "")
+
+		// assert
+		assert.Equal(t, http.StatusCreated, status)
+		assert.Equal(t, "Kitchen", created["name"])
+		assert.Equal(t, created["id"], active["id"])
+	})
+
+	t.Run("change a draft which is not active", func(t *testing.T) {
+		// act
+		status, changed := do(t, "PUT", fmt.Sprintf("/quotes/%s/address", first["id"]),
+			`{"address": "Harbour 2", "city": "Hamburg", "country": "DE"}`)
+		_, active := do(t, "GET", "/quote", "")
+
+		// assert
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, first["id"], changed["id"])
+		assert.Equal(t, "Hamburg", changed["address"].(map[string]interface{})["city"])
+		assert.NotEqual(t, first["id"], active["id"])
+	})
+
+	t.Run("list with the active draft first", func(t *testing.T) {
+		// act
+		status, list := do(t, "GET", "/drafts", "")
+
+		// assert
+		assert.Equal(t, http.StatusOK, status)
+		drafts := list["drafts"].([]interface{})
+		assert.Len(t, drafts, 2)
+		assert.Equal(t, list["active_id"], drafts[0].(map[string]interface{})["id"])
+		assert.Equal(t, first["id"], drafts[1].(map[string]interface{})["id"])
+	})
+
+	t.Run("activate and rename", func(t *testing.T) {
+		// act
+		status, _ := do(t, "POST", fmt.Sprintf("/quotes/%s/activate", first["id"]), "")
+		_, renamed := do(t, "PUT", fmt.Sprintf("/quotes/%s/name", first["id"]), `{"name": "Bathroom"}`)
+		_, active := do(t, "GET", "/quote", "")
+
+		// assert
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Bathroom", renamed["name"])
+		assert.Equal(t, first["id"], active["id"])
+	})
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+	}{
+		{name: "processed quote", method: "POST", path: fmt.Sprintf("/quotes/%s/activate", processed.UUID), status: http.StatusConflict},
+		{name: "unknown quote", method: "POST", path: fmt.Sprintf("/quotes/%s/activate", uuid.New()), status: http.StatusNotFound},
+		{name: "blank name", method: "POST", path: "/drafts", body: `{"name": " "}`, status: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// act
+			status, _ := do(t, tt.method, tt.path, tt.body)
+
+			// assert
+			assert.Equal(t, tt.status, status)
+		})
+	}
+}
+
+func TestApiHandlerReorder(t *testing.T) {
+	// arrange
+	customerUUID := uuid.New()
+	tc := newTestApiHandler(t)
+
+	processed := types.NewQuote(uuid.New(), customerUUID)
+	processed.Revision = 1
+	processed.Status = types.QuoteStatusDone
+	processed.Address = &types.Address{Address: "Main St. 1", City: "Berlin", Country: "DE"}
+	assert.NoError(t, tc.quotes.Save(context.Background(), processed))
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "with address", body: fmt.Sprintf(`{"source_id": "%s", "address": true}`, processed.UUID), status: http.StatusOK},
+		{name: "invalid source", body: `{"source_id": "x"}`, status: http.StatusBadRequest},
+		{name: "unknown source", body: fmt.Sprintf(`{"source_id": "%s"}`, uuid.New()), status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/reorder", customerUUID), strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID.String()))
+			req.Header.Set("Content-Type", "application/json")
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tt.status, rec.Result().StatusCode)
+			if tt.status == http.StatusOK {
+				var response struct {
+					Copied  []interface{} `json:"copied"`
+					Skipped []interface{} `json:"skipped"`
+					Quote   struct {
+						ID      uuid.UUID      `json:"id"`
+						Status  string         `json:"status"`
+						Address *types.Address `json:"address"`
+					} `json:"quote"`
+				}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Empty(t, response.Copied)
+				assert.Empty(t, response.Skipped)
+				assert.NotEqual(t, processed.UUID, response.Quote.ID)
+				assert.Equal(t, "draft", response.Quote.Status)
+				assert.NotNil(t, response.Quote.Address)
+			}
+		})
+	}
+}
+
+func TestApiHandlerTemplates(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.NewString()
+
+	template := &types.Template{
+		UUID:  uuid.New(),
+		Name:  "Starter kit",
+		Lines: []types.TemplateLine{{ProductID: uuid.New(), Quantity: 2}},
+	}
+	assert.NoError(t, tc.templates.SaveTemplate(context.Background(), template))
+
+	body := fmt.Sprintf(`{"name": "Kit", "lines": [{"product_id": "%s", "qty": 1}]}`, uuid.New())
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		subject string
+		roles   []string
+		status  int
+	}{
+		{name: "list", method: "GET", path: "/v1/templates", subject: customerUUID, status: http.StatusOK},
+		{name: "get", method: "GET", path: "/v1/templates/" + template.UUID.String(), subject: customerUUID, status: http.StatusOK},
+		{name: "get unknown", method: "GET", path: "/v1/templates/" + uuid.NewString(), subject: customerUUID, status: http.StatusNotFound},
+		{name: "create not staff", method: "POST", path: "/v1/templates", body: body, subject: customerUUID, status: http.StatusForbidden},
+		{name: "create invalid product", method: "POST", path: "/v1/templates", body: `{"name": "Kit", "lines": [{"product_id": "x", "qty": 1}]}`, subject: uuid.NewString(), roles: []string{auth.RoleStaff}, status: http.StatusBadRequest},
+		{name: "update unknown", method: "PUT", path: "/v1/templates/" + uuid.NewString(), body: body, subject: uuid.NewString(), roles: []string{auth.RoleStaff}, status: http.StatusNotFound},
+		{name: "apply unknown", method: "POST", path: fmt.Sprintf("/v1/customers/%s/quote/templates/%s", customerUUID, uuid.New()), subject: customerUUID, status: http.StatusNotFound},
+		{name: "delete", method: "DELETE", path: "/v1/templates/" + template.UUID.String(), subject: uuid.NewString(), roles: []string{auth.RoleStaff}, status: http.StatusNoContent},
+		{name: "get deleted", method: "GET", path: "/v1/templates/" + template.UUID.String(), subject: customerUUID, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, tt.subject, tt.roles...))
+			if tt.body != "" {
+				req.Header.Set("Content-Type", "application/json")
+			}
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tt.status, rec.Result().StatusCode)
+			if tt.name == "list" {
+				var response struct {
+					Templates []struct {
+						ID   uuid.UUID `json:"id"`
+						Name string    `json:"name"`
+					} `json:"templates"`
+				}
+				assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+				assert.Len(t, response.Templates, 1)
+				assert.Equal(t, template.UUID, response.Templates[0].ID)
+			}
+		})
+	}
+}
 
-	assert.Equal(t, expectedQuote, quote)
+func TestApiHandlerShareQuote(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.New()
+	customerToken := newTestToken(t, customerUUID.String())
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Revision = 1
+	quote.Status = types.QuoteStatusDone
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	serve := func(method string, path string, body string, token string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		assert.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		req.Header.Set("User-Agent", "purchasing")
+		tc.router().ServeHTTP(rec, req)
+
+		return rec
+	}
+	sharesPath := fmt.Sprintf("/v1/customers/%s/quotes/%s/shares", customerUUID, quote.UUID)
+
+	// act
+	created := serve("POST", sharesPath, `{"expires_in": 3600}`, customerToken)
+
+	// assert
+	assert.Equal(t, http.StatusCreated, created.Result().StatusCode)
+	var share struct {
+		ID       uuid.UUID `json:"id"`
+		Revision int       `json:"revision"`
+		Status   string    `json:"status"`
+		Token    string    `json:"token"`
+	}
+	assert.NoError(t, json.Unmarshal(created.Body.Bytes(), &share))
+	assert.Equal(t, 1, share.Revision)
+	assert.Equal(t, "active", share.Status)
+
+	shared := serve("GET", "/v1/shared/quotes/"+share.Token, "", "")
+	assert.Equal(t, http.StatusOK, shared.Result().StatusCode)
+	assert.Equal(t, "no-store", shared.Header().Get("Cache-Control"))
+	var sharedQuote struct {
+		Revision int                    `json:"revision"`
+		Quote    map[string]interface{} `json:"quote"`
+	}
+	assert.NoError(t, json.Unmarshal(shared.Body.Bytes(), &sharedQuote))
+	assert.Equal(t, 1, sharedQuote.Revision)
+	assert.Equal(t, "done", sharedQuote.Quote["status"])
+	assert.NotContains(t, sharedQuote.Quote, "updated_by")
+
+	assert.Equal(t, http.StatusMethodNotAllowed, serve("PUT", "/v1/shared/quotes/"+share.Token, "{}", "").Result().StatusCode)
+	assert.Equal(t, http.StatusNotFound, serve("GET", "/v1/shared/quotes/"+share.Token+"x", "", "").Result().StatusCode)
+
+	accesses := serve("GET", fmt.Sprintf("%s/%s/accesses", sharesPath, share.ID), "", customerToken)
+	assert.Equal(t, http.StatusOK, accesses.Result().StatusCode)
+	var accessList struct {
+		Accesses []struct {
+			UserAgent string `json:"user_agent"`
+		} `json:"accesses"`
+	}
+	assert.NoError(t, json.Unmarshal(accesses.Body.Bytes(), &accessList))
+	assert.Len(t, accessList.Accesses, 1)
+	assert.Equal(t, "purchasing", accessList.Accesses[0].UserAgent)
+
+	assert.Equal(t, http.StatusNoContent, serve("DELETE", fmt.Sprintf("%s/%s", sharesPath, share.ID), "", customerToken).Result().StatusCode)
+	assert.Equal(t, http.StatusGone, serve("GET", "/v1/shared/quotes/"+share.Token, "", "").Result().StatusCode)
+	assert.Equal(t, http.StatusForbidden, serve("POST", sharesPath, `{}`, newTestToken(t, uuid.NewString())).Result().StatusCode)
+}
+
+func TestApiHandlerLoggerRedactsShareToken(t *testing.T) {
+	// arrange
+	var logged bytes.Buffer
+	r := chi.NewRouter()
+	r.Use(handler.LoggerMiddleware(log.New(&logged, "", 0)))
+	r.Get("/shared/quotes/{token}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r.Get("/customers/{customerID}/quote", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	customerPath := fmt.Sprintf("/customers/%s/quote", uuid.New())
+
+	// act
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/shared/quotes/secret-share-token", nil))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", customerPath, nil))
+
+	// assert
+	assert.NotContains(t, logged.String(), "secret-share-token")
+	assert.Contains(t, logged.String(), "/shared/quotes/[redacted]")
+	assert.Contains(t, logged.String(), customerPath)
+}
+
+func TestApiHandlerAcceptQuote(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.New()
+	customerToken := newTestToken(t, customerUUID.String())
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 2
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 2, Amount: 100, TaxAmount: 19, TotalAmount: 119}}
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	serve := func(path string, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", path, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+customerToken)
+		req.Header.Set("Content-Type", "application/json")
+		req.RemoteAddr = "203.0.113.7:52100"
+		tc.router().ServeHTTP(rec, req)
+
+		return rec
+	}
+	acceptPath := fmt.Sprintf("/v1/customers/%s/quote/accept", customerUUID)
+	quotePath := fmt.Sprintf("/v1/customers/%s/quotes/%s", customerUUID, quote.UUID)
+
+	// act
+	outdated := serve(acceptPath, `{"name": "Jane Doe", "email": "jane@example.com", "revision": 1, "terms_version": "2026-01"}`)
+	accepted := serve(acceptPath, `{"name": "Jane Doe", "email": "jane@example.com", "revision": 2, "terms_version": "2026-01"}`)
+
+	// assert
+	assert.Equal(t, http.StatusPreconditionFailed, outdated.Result().StatusCode)
+	assert.Equal(t, http.StatusOK, accepted.Result().StatusCode)
+	assert.NotEmpty(t, accepted.Header().Get("ETag"))
+	var response struct {
+		ID         uuid.UUID `json:"id"`
+		Status     string    `json:"status"`
+		Acceptance struct {
+			Name         string `json:"name"`
+			IP           string `json:"ip"`
+			Revision     int    `json:"revision"`
+			RevisionHash string `json:"revision_hash"`
+		} `json:"acceptance"`
+	}
+	assert.NoError(t, json.Unmarshal(accepted.Body.Bytes(), &response))
+	assert.Equal(t, quote.UUID, response.ID)
+	assert.Equal(t, "accepted", response.Status)
+	assert.Equal(t, "Jane Doe", response.Acceptance.Name)
+	assert.Equal(t, "203.0.113.7", response.Acceptance.IP)
+	assert.Equal(t, 2, response.Acceptance.Revision)
+	assert.Equal(t, quote.ContentHash(), response.Acceptance.RevisionHash)
+
+	again := serve(quotePath+"/accept", `{"name": "Jane Doe", "email": "jane@example.com", "revision": 3, "terms_version": "2026-01"}`)
+	assert.Equal(t, http.StatusConflict, again.Result().StatusCode)
+
+	// the active draft is a new one now, which the customer segment has to accept before processing
+	required := serve(fmt.Sprintf("/v1/customers/%s/quote/process", customerUUID), "")
+	assert.Equal(t, http.StatusConflict, required.Result().StatusCode)
+	assert.Contains(t, required.Body.String(), "acceptance-required")
+
+	// the accepted quote passes the acceptance and reaches the order service, which is not available in the test
+	processed := serve(quotePath+"/process", "")
+	assert.Equal(t, http.StatusServiceUnavailable, processed.Result().StatusCode)
+}
+
+func TestApiHandlerAcceptQuoteClientIP(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		expectedIP   string
+	}{
+		{name: "direct client", remoteAddr: "203.0.113.7:52100", forwardedFor: "198.51.100.9", expectedIP: "203.0.113.7"},
+		{name: "trusted proxy", remoteAddr: "10.0.0.2:41000", forwardedFor: "198.51.100.9", expectedIP: "198.51.100.9"},
+		{name: "spoofed chain", remoteAddr: "10.0.0.2:41000", forwardedFor: "192.0.2.1, 203.0.113.7, 10.0.0.3", expectedIP: "203.0.113.7"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			tc := newTestApiHandler(t)
+			customerUUID := uuid.New()
+
+			quote := types.NewQuote(uuid.New(), customerUUID)
+			quote.Number = "Q-2026-000001"
+			quote.Revision = 1
+			quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 1, Amount: 100, TaxAmount: 19, TotalAmount: 119}}
+			assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/accept", customerUUID),
+				strings.NewReader(`{"name": "Jane Doe", "email": "jane@example.com", "revision": 1, "terms_version": "2026-01"}`))
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID.String()))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			req.RemoteAddr = tt.remoteAddr
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+			var response struct {
+				Acceptance struct {
+					IP string `json:"ip"`
+				} `json:"acceptance"`
+			}
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+			assert.Equal(t, tt.expectedIP, response.Acceptance.IP)
+		})
+	}
+}
+
+func TestApiHandlerAcceptQuoteStaff(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 1
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 1, Amount: 100, TaxAmount: 19, TotalAmount: 119}}
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("POST", fmt.Sprintf("/v1/customers/%s/quote/accept", customerUUID),
+		strings.NewReader(`{"name": "Jane Doe", "email": "jane@example.com", "revision": 1, "terms_version": "2026-01"}`))
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, uuid.NewString(), auth.RoleStaff))
+	req.Header.Set("Content-Type", "application/json")
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, rec.Result().StatusCode)
+	assert.Contains(t, rec.Body.String(), "only the customer can accept the quote")
+
+	stored, err := tc.quotes.FindByID(context.Background(), quote.UUID)
+	assert.NoError(t, err)
+	assert.Equal(t, types.QuoteStatusDraft, stored.Status)
+}
+
+func TestApiHandlerAgentProcessAcceptedQuote(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.New()
+	agentUUID := uuid.New()
+	tc.assignments.customers[customerUUID] = agentUUID
+	customerToken := newTestToken(t, customerUUID.String())
+	agentToken := newTestToken(t, agentUUID.String(), auth.RoleSalesAgent)
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 1
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 1, Amount: 100, TaxAmount: 19, TotalAmount: 119}}
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	serve := func(path string, body string, token string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", path, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		tc.router().ServeHTTP(rec, req)
+
+		return rec
+	}
+	agentPath := fmt.Sprintf("/v1/agents/%s/customers/%s", agentUUID, customerUUID)
+	accepted := serve(fmt.Sprintf("/v1/customers/%s/quote/accept", customerUUID),
+		`{"name": "Jane Doe", "email": "jane@example.com", "revision": 1, "terms_version": "2026-01"}`, customerToken)
+	assert.Equal(t, http.StatusOK, accepted.Result().StatusCode)
+
+	// act
+	processed := serve(fmt.Sprintf("%s/quotes/%s/process", agentPath, quote.UUID), "", agentToken)
+
+	// assert
+	// the accepted quote passes the acceptance and reaches the order service, which is not available in the test
+	assert.Equal(t, http.StatusServiceUnavailable, processed.Result().StatusCode)
+}
+
+func TestApiHandlerNegotiateQuote(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.New()
+	agentUUID := uuid.New()
+	tc.assignments.customers[customerUUID] = agentUUID
+	customerToken := newTestToken(t, customerUUID.String())
+	agentToken := newTestToken(t, agentUUID.String(), auth.RoleSalesAgent)
+
+	productUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 1
+	quote.Products = []types.Product{{ProductID: productUUID, Quantity: 10, Amount: 500, TaxAmount: 95, TotalAmount: 595}}
+	quote.Amount, quote.TaxAmount, quote.TotalAmount = 500, 95, 595
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	serve := func(method string, path string, body string, token string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest(method, path, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		tc.router().ServeHTTP(rec, req)
+
+		return rec
+	}
+	customerPath := fmt.Sprintf("/v1/customers/%s/quote/offers", customerUUID)
+	agentPath := fmt.Sprintf("/v1/agents/%s/customers/%s/quote/offers", agentUUID, customerUUID)
+	offer := func(price float64) string {
+		return fmt.Sprintf(`{"lines": [{"product_id": "%s", "price": %g}], "note": "volume order"}`, productUUID, price)
+	}
+
+	// act
+	proposed := serve("POST", customerPath, offer(40), customerToken)
+	countered := serve("POST", agentPath, offer(45), agentToken)
+	pending := serve("POST", agentPath, offer(44), agentToken)
+	listed := serve("GET", customerPath, "", customerToken)
+
+	// assert
+	assert.Equal(t, http.StatusCreated, proposed.Result().StatusCode)
+	assert.NotEmpty(t, proposed.Header().Get("ETag"))
+	assert.Equal(t, http.StatusCreated, countered.Result().StatusCode)
+	assert.Equal(t, http.StatusConflict, pending.Result().StatusCode)
+	assert.Contains(t, pending.Body.String(), "offer-pending")
+	assert.Equal(t, http.StatusOK, listed.Result().StatusCode)
+
+	var negotiation struct {
+		AgreedOffer *int `json:"agreed_offer"`
+		Offers      []struct {
+			Number   int    `json:"number"`
+			Side     string `json:"side"`
+			Status   string `json:"status"`
+			Revision int    `json:"revision"`
+		} `json:"offers"`
+		Quote struct {
+			Revision int     `json:"revision"`
+			Amount   float64 `json:"amount"`
+		} `json:"quote"`
+	}
+	assert.NoError(t, json.Unmarshal(listed.Body.Bytes(), &negotiation))
+	assert.Nil(t, negotiation.AgreedOffer)
+	assert.Len(t, negotiation.Offers, 2)
+	assert.Equal(t, "customer", negotiation.Offers[0].Side)
+	assert.Equal(t, "countered", negotiation.Offers[0].Status)
+	assert.Equal(t, 2, negotiation.Offers[0].Revision)
+	assert.Equal(t, "sales", negotiation.Offers[1].Side)
+	assert.Equal(t, "open", negotiation.Offers[1].Status)
+	assert.Equal(t, 3, negotiation.Offers[1].Revision)
+	assert.Equal(t, 3, negotiation.Quote.Revision)
+	// the prices only apply once an offer is accepted
+	assert.Equal(t, 500.0, negotiation.Quote.Amount)
+
+	invalid := serve("POST", customerPath, `{"lines": [{"product_id": "`+uuid.NewString()+`", "price": 1}]}`, customerToken)
+	assert.Equal(t, http.StatusUnprocessableEntity, invalid.Result().StatusCode)
+}
+
+func TestApiHandlerNegotiateQuoteDiscountPermission(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	customerUUID := uuid.New()
+	agentUUID := uuid.New()
+	tc.assignments.customers[customerUUID] = agentUUID
+	customerToken := newTestToken(t, customerUUID.String())
+	agentToken := newTestToken(t, agentUUID.String(), auth.RoleSalesAgent)
+	managerToken := newTestToken(t, agentUUID.String(), auth.RoleSalesManager)
+	staffToken := newTestToken(t, uuid.NewString(), auth.RoleStaff)
+
+	productUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 1
+	quote.Products = []types.Product{{ProductID: productUUID, Quantity: 10, Amount: 500, TaxAmount: 95, TotalAmount: 595}}
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	serve := func(path string, body string, token string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := http.NewRequest("POST", path, strings.NewReader(body))
+		assert.NoError(t, err)
+		req.Header.Set("Authorization", "Bearer "+token)
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		tc.router().ServeHTTP(rec, req)
+
+		return rec
+	}
+	customerPath := fmt.Sprintf("/v1/customers/%s/quote/offers", customerUUID)
+	agentPath := fmt.Sprintf("/v1/agents/%s/customers/%s/quote/offers", agentUUID, customerUUID)
+	discounted := fmt.Sprintf(`{"lines": [{"product_id": "%s", "price": 50, "discount_percent": 5}]}`, productUUID)
+
+	proposed := serve(customerPath, fmt.Sprintf(`{"lines": [{"product_id": "%s", "price": 50, "discount_percent": 10}]}`, productUUID), customerToken)
+	assert.Equal(t, http.StatusCreated, proposed.Result().StatusCode)
+
+	// act
+	agentAccepted := serve(agentPath+"/1/accept", "", agentToken)
+	agentCountered := serve(agentPath, discounted, agentToken)
+	staffAccepted := serve(customerPath+"/1/accept", "", staffToken)
+	staffCountered := serve(customerPath, discounted, staffToken)
+	managerAccepted := serve(agentPath+"/1/accept", "", managerToken)
+	managerCountered := serve(agentPath, discounted, managerToken)
+
+	// assert
+	assert.Equal(t, http.StatusForbidden, agentAccepted.Result().StatusCode)
+	assert.Contains(t, agentAccepted.Body.String(), "roles of the token do not grant the operation")
+	assert.Equal(t, http.StatusForbidden, agentCountered.Result().StatusCode)
+	assert.Contains(t, agentCountered.Body.String(), "roles of the token do not grant the operation")
+	// staff negotiate for sales on the customer routes and need the permission as well
+	assert.Equal(t, http.StatusForbidden, staffAccepted.Result().StatusCode)
+	assert.Equal(t, http.StatusForbidden, staffCountered.Result().StatusCode)
+	// the manager passes the permission, the catalog pricing the accepted offer is not available in the test
+	assert.Equal(t, http.StatusServiceUnavailable, managerAccepted.Result().StatusCode)
+	assert.Equal(t, http.StatusCreated, managerCountered.Result().StatusCode)
+}
+
+func TestApiHandlerExportQuote(t *testing.T) {
+	// arrange
+	customerUUID := uuid.New()
+	tc := newTestApiHandler(t)
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Revision = 1
+	quote.Status = types.QuoteStatusDone
+	assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+
+	rec := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", fmt.Sprintf("/v1/customers/%s/quotes/%s/export?format=ndjson", customerUUID, quote.UUID), nil)
+	assert.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer "+newTestToken(t, customerUUID.String()))
+
+	// act
+	tc.router().ServeHTTP(rec, req)
+
+	// assert
+	assert.Equal(t, http.StatusOK, rec.Result().StatusCode)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+	assert.Equal(t, fmt.Sprintf(`attachment; filename="quote-%s.ndjson"`, quote.UUID), rec.Header().Get("Content-Disposition"))
+
+	var exported struct {
+		ID         uuid.UUID `json:"id"`
+		CustomerID uuid.UUID `json:"customer_id"`
+		Status     string    `json:"status"`
+	}
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &exported))
+	assert.Equal(t, quote.UUID, exported.ID)
+	assert.Equal(t, customerUUID, exported.CustomerID)
+	assert.Equal(t, "done", exported.Status)
+}
+
+func TestApiHandlerExportQuotes(t *testing.T) {
+	// arrange
+	tc := newTestApiHandler(t)
+	updatedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	quotes := []*types.Quote{
+		types.NewQuote(uuid.New(), uuid.New()),
+		types.NewQuote(uuid.New(), uuid.New()),
+		types.NewQuote(uuid.New(), uuid.New()),
+	}
+	for i, quote := range quotes {
+		quote.Revision = 1
+		quote.Status = types.QuoteStatusDone
+		quote.UpdatedAt = updatedAt.AddDate(0, 0, i)
+		assert.NoError(t, tc.quotes.Save(context.Background(), quote))
+	}
+	quotes[1].Status = types.QuoteStatusDraft
+	quotes[1].Revision = 2
+	assert.NoError(t, tc.quotes.Save(context.Background(), quotes[1]))
+
+	tests := []struct {
+		name   string
+		roles  []string
+		query  string
+		status int
+		quotes []*types.Quote
+	}{
+		{name: "every quote", roles: []string{auth.RoleStaff}, status: http.StatusOK, quotes: quotes},
+		{name: "by status", roles: []string{auth.RoleStaff}, query: "status=done", status: http.StatusOK, quotes: []*types.Quote{quotes[0], quotes[2]}},
+		{name: "by update", roles: []string{auth.RoleStaff}, query: "updated_from=2026-03-02T00:00:00Z&updated_to=2026-03-03T12:00:00Z", status: http.StatusOK, quotes: []*types.Quote{quotes[1]}},
+		{name: "invalid status", roles: []string{auth.RoleStaff}, query: "status=lost", status: http.StatusBadRequest},
+		{name: "not staff", status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			req, err := http.NewRequest("GET", "/v1/exports/quotes?"+tt.query, nil)
+			assert.NoError(t, err)
+			req.Header.Set("Authorization", "Bearer "+newTestToken(t, uuid.NewString(), tt.roles...))
+
+			// act
+			tc.router().ServeHTTP(rec, req)
+
+			// assert
+			assert.Equal(t, tt.status, rec.Result().StatusCode)
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+			rows := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+			assert.Len(t, rows, len(tt.quotes)+1)
+			for i, quote := range tt.quotes {
+				assert.True(t, strings.HasPrefix(rows[i+1], quote.UUID.String()+","))
+			}
+		})
+	}
 }
@@ -0,0 +1,326 @@
+// Package document renders quotes as PDF documents.
+package document
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/google/uuid"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+//go:embed templates/quote.tmpl
+var templates embed.FS
+
+const (
+	defaultTemplate    string = "templates/quote.tmpl"
+	defaultAccentColor string = "#1F4E79"
+)
+
+type (
+	Config struct {
+		CompanyName    string
+		CompanyAddress string
+		Currency       string
+		// AccentColor is the hex color of the header band and the table head, e.g. `#1F4E79`.
+		// The default color is used when it is empty.
+		AccentColor string
+		// ValidityDays is the number of days the quote is valid after its last change.
+		ValidityDays int
+		// TemplatePath replaces the embedded text template, it defines the title, intro, terms and footer.
+		TemplatePath string
+	}
+
+	// productCatalog names the products of the lines.
+	productCatalog interface {
+		GetProductByID(ctx context.Context, productID uuid.UUID) (*catalog.Product, error)
+	}
+
+	// Renderer draws the layout of the document, the texts of the document are defined by the template.
+	Renderer struct {
+		config   Config
+		accent   [3]int
+		template *template.Template
+		catalog  productCatalog
+	}
+
+	// documentData is the view of a quote the template is executed with.
+	documentData struct {
+		Number        string
+		Status        string
+		IssuedAt      time.Time
+		ValidUntil    time.Time
+		Currency      string
+		PaymentMethod string
+		Company       companyData
+	}
+
+	companyData struct {
+		Name    string
+		Address string
+	}
+)
+
+func NewRenderer(config Config, catalogClient productCatalog) (*Renderer, error) {
+	if config.AccentColor == "" {
+		config.AccentColor = defaultAccentColor
+	}
+
+	accent, err := parseColor(config.AccentColor)
+	if err != nil {
+		return nil, fmt.Errorf("Document::NewRenderer : %w", err)
+	}
+
+	var tmpl *template.Template
+	if config.TemplatePath != "" {
+		tmpl, err = template.ParseFiles(config.TemplatePath)
+	} else {
+		tmpl, err = template.ParseFS(templates, defaultTemplate)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Document::NewRenderer : %w", err)
+	}
+
+	return &Renderer{
+		config:   config,
+		accent:   accent,
+		template: tmpl,
+		catalog:  catalogClient,
+	}, nil
+}
+
+// Render writes the quote as an A4 PDF document.
+func (r *Renderer) Render(ctx context.Context, w io.Writer, quote *types.Quote) error {
+	data := r.data(quote)
+
+	products, err := r.productNames(ctx, quote)
+	if err != nil {
+		return fmt.Errorf("Document::Render : %w", err)
+	}
+
+	texts := make(map[string]string)
+	for _, name := range []string{"title", "intro", "terms", "footer"} {
+		var text bytes.Buffer
+		if err := r.template.ExecuteTemplate(&text, name, data); err != nil {
+			return fmt.Errorf("Document::Render : %w", err)
+		}
+		texts[name] = strings.TrimSpace(text.String())
+	}
+
+	pdf := fpdf.New("P", "mm", "A4", "")
+	// the dates of the quote keep the document reproducible
+	pdf.SetCreationDate(quote.UpdatedAt)
+	pdf.SetModificationDate(quote.UpdatedAt)
+	pdf.SetCatalogSort(true)
+	pdf.SetTitle(texts["title"], true)
+	pdf.SetAuthor(r.config.CompanyName, true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(110, 110, 110)
+		pdf.CellFormat(0, 5, tr(texts["footer"]), "T", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	r.header(pdf, tr, texts["title"])
+	r.details(pdf, tr, quote, data)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.MultiCell(0, 5, tr(texts["intro"]), "", "L", false)
+	pdf.Ln(4)
+
+	r.lines(pdf, tr, quote, products)
+	r.totals(pdf, tr, quote)
+
+	pdf.Ln(8)
+	pdf.SetFont("Helvetica", "", 9)
+	pdf.MultiCell(0, 5, tr(texts["terms"]), "", "L", false)
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("Document::Render : %w", err)
+	}
+
+	return nil
+}
+
+func (r *Renderer) data(quote *types.Quote) documentData {
+	data := documentData{
+		Number:     quote.Reference(),
+		Status:     string(quote.Status),
+		IssuedAt:   quote.UpdatedAt,
+		ValidUntil: quote.UpdatedAt.AddDate(0, 0, r.config.ValidityDays),
+		Currency:   r.config.Currency,
+		Company: companyData{
+			Name:    r.config.CompanyName,
+			Address: r.config.CompanyAddress,
+		},
+	}
+	if quote.Payment != nil {
+		data.PaymentMethod = quote.Payment.PaymentMethod
+	}
+
+	return data
+}
+
+// productNames returns the SKU of every line, or its product ID when the product is not in the catalog anymore.
+func (r *Renderer) productNames(ctx context.Context, quote *types.Quote) ([]string, error) {
+	names := make([]string, 0, len(quote.Products))
+	for _, product := range quote.Products {
+		productInfo, err := r.catalog.GetProductByID(ctx, product.ProductID)
+		switch {
+		case errors.Is(err, catalog.ErrProductNotFound):
+			names = append(names, product.ProductID.String())
+		case err != nil:
+			return nil, err
+		case productInfo.SKU == "":
+			names = append(names, product.ProductID.String())
+		default:
+			names = append(names, productInfo.SKU)
+		}
+	}
+
+	return names, nil
+}
+
+func (r *Renderer) header(pdf *fpdf.Fpdf, tr func(string) string, title string) {
+	pdf.SetFillColor(r.accent[0], r.accent[1], r.accent[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 16)
+	pdf.CellFormat(0, 12, tr(r.config.CompanyName), "", 1, "L", true, 0, "")
+	pdf.Ln(6)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "B", 14)
+	pdf.CellFormat(0, 8, tr(title), "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+}
+
+func (r *Renderer) details(pdf *fpdf.Fpdf, tr func(string) string, quote *types.Quote, data documentData) {
+	address := "-"
+	if quote.Address != nil {
+		address = fmt.Sprintf("%s, %s, %s", quote.Address.Address, quote.Address.City, quote.Address.Country)
+	}
+	payment := "-"
+	if data.PaymentMethod != "" {
+		payment = data.PaymentMethod
+	}
+
+	rows := [][2]string{
+		{"Quote number", data.Number},
+		{"Status", data.Status},
+		{"Date", data.IssuedAt.Format("2006-01-02")},
+		{"Valid until", data.ValidUntil.Format("2006-01-02")},
+		{"Address", address},
+		{"Payment method", payment},
+	}
+	for _, row := range rows {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(40, 6, tr(row[0]), "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(0, 6, tr(row[1]), "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+}
+
+// columns are the widths of the line table, they fill the 190mm between the margins.
+var columns = []struct {
+	title string
+	width float64
+	align string
+}{
+	{"Product", 70, "L"},
+	{"Qty", 15, "R"},
+	{"Discount", 20, "R"},
+	{"Net", 28, "R"},
+	{"Tax", 27, "R"},
+	{"Total", 30, "R"},
+}
+
+func (r *Renderer) lines(pdf *fpdf.Fpdf, tr func(string) string, quote *types.Quote, products []string) {
+	pdf.SetFillColor(r.accent[0], r.accent[1], r.accent[2])
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont("Helvetica", "B", 9)
+	for _, column := range columns {
+		pdf.CellFormat(column.width, 7, tr(column.title), "", 0, column.align, true, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetTextColor(0, 0, 0)
+	pdf.SetFont("Helvetica", "", 9)
+	for i, product := range quote.Products {
+		discount := ""
+		if product.Discount > 0 {
+			discount = strconv.FormatFloat(product.Discount, 'f', -1, 64) + " %"
+		}
+
+		cells := []string{
+			products[i],
+			strconv.Itoa(product.Quantity),
+			discount,
+			r.money(product.Amount),
+			r.money(product.TaxAmount),
+			r.money(product.TotalAmount),
+		}
+		for j, column := range columns {
+			pdf.CellFormat(column.width, 6, tr(cells[j]), "B", 0, column.align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	if len(quote.Products) == 0 {
+		pdf.CellFormat(0, 6, tr("The quote has no products."), "B", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+}
+
+func (r *Renderer) totals(pdf *fpdf.Fpdf, tr func(string) string, quote *types.Quote) {
+	rows := [][2]string{
+		{"Net amount", r.money(quote.Amount)},
+		{"Taxes", r.money(quote.TaxAmount)},
+		{"Total", r.money(quote.TotalAmount)},
+	}
+	for i, row := range rows {
+		style := ""
+		if i == len(rows)-1 {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, 10)
+		pdf.CellFormat(150, 6, tr(row[0]), "", 0, "R", false, 0, "")
+		pdf.CellFormat(40, 6, tr(row[1]), "", 1, "R", false, 0, "")
+	}
+}
+
+func (r *Renderer) money(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64) + " " + r.config.Currency
+}
+
+// parseColor reads a `#RRGGBB` color.
+func parseColor(hex string) ([3]int, error) {
+	var color [3]int
+	value, found := strings.CutPrefix(hex, "#")
+	if !found || len(value) != 6 {
+		return color, fmt.Errorf("color %q must be given as #RRGGBB", hex)
+	}
+
+	for i := range color {
+		component, err := strconv.ParseUint(value[i*2:i*2+2], 16, 8)
+		if err != nil {
+			return color, fmt.Errorf("color %q must be given as #RRGGBB: %w", hex, err)
+		}
+		color[i] = int(component)
+	}
+
+	return color, nil
+}
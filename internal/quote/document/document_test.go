@@ -0,0 +1,206 @@
+package document_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"app/internal/catalog"
+	"app/internal/quote/document"
+	"app/internal/quote/types"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// testCatalog knows the products of its map, every other product is not in the catalog.
+type testCatalog map[uuid.UUID]string
+
+func (c testCatalog) GetProductByID(ctx context.Context, productID uuid.UUID) (*catalog.Product, error) {
+	sku, ok := c[productID]
+	if !ok {
+		return nil, catalog.ErrProductNotFound
+	}
+
+	return &catalog.Product{ProductID: productID, SKU: sku}, nil
+}
+
+var (
+	streamPattern = regexp.MustCompile(`(?s)stream\r?\n(.*?)\r?\nendstream`)
+	textPattern   = regexp.MustCompile(`\(((?:\\.|[^\\)])*)\)\s*Tj`)
+)
+
+// extractText returns the strings shown by the content streams of the document, one per line.
+func extractText(t *testing.T, pdf []byte) string {
+	var text strings.Builder
+	for _, match := range streamPattern.FindAllSubmatch(pdf, -1) {
+		reader, err := zlib.NewReader(bytes.NewReader(match[1]))
+		if err != nil {
+			// fonts and other binary streams are not compressed content
+			continue
+		}
+		content, err := io.ReadAll(reader)
+		assert.NoError(t, err)
+
+		for _, shown := range textPattern.FindAllSubmatch(content, -1) {
+			// the core fonts are encoded in cp1252, which matches Latin-1 for the letters used here
+			line := make([]rune, 0, len(shown[1]))
+			for _, b := range shown[1] {
+				line = append(line, rune(b))
+			}
+			text.WriteString(strings.NewReplacer(`\(`, "(", `\)`, ")", `\\`, `\`).Replace(string(line)) + "\n")
+		}
+	}
+
+	return text.String()
+}
+
+func assertGolden(t *testing.T, name string, pdf []byte) {
+	actual := extractText(t, pdf)
+
+	path := filepath.Join("testdata", name+".golden.txt")
+	if *update {
+		assert.NoError(t, os.WriteFile(path, []byte(actual), 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), actual)
+}
+
+type unavailableCatalog struct{}
+
+func (unavailableCatalog) GetProductByID(ctx context.Context, productID uuid.UUID) (*catalog.Product, error) {
+	return nil, catalog.ErrUnavailable
+}
+
+func newTestRenderer(t *testing.T) *document.Renderer {
+	renderer, err := document.NewRenderer(document.Config{
+		CompanyName:    "Meisterwerk GmbH",
+		CompanyAddress: "Hauptstraße 1, 10115 Berlin",
+		Currency:       "EUR",
+		AccentColor:    "#1F4E79",
+		ValidityDays:   30,
+	}, testCatalog{
+		// the second product of the full quote is discontinued, it is named by its ID
+		uuid.MustParse("f2d6a7a2-5a1b-4c47-8c1e-0a9b8c7d6e5f"): "DRILL-500",
+	})
+	assert.NoError(t, err)
+
+	return renderer
+}
+
+func TestRenderFull(t *testing.T) {
+	// arrange
+	quote := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.New())
+	quote.Number = "Q-2026-000123"
+	quote.UpdatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	quote.Status = types.QuoteStatusDone
+	quote.Address = &types.Address{Address: "Unter den Linden 1", City: "Berlin", Country: "DE"}
+	quote.Payment = &types.Payment{PaymentMethod: "invoice"}
+	quote.Products = []types.Product{
+		{
+			ProductID:   uuid.MustParse("f2d6a7a2-5a1b-4c47-8c1e-0a9b8c7d6e5f"),
+			Quantity:    2,
+			Amount:      200,
+			TaxAmount:   38,
+			TotalAmount: 238,
+		},
+		{
+			ProductID:   uuid.MustParse("0e4d3c2b-1a09-4f8e-b7d6-c5b4a3928170"),
+			Quantity:    1,
+			Discount:    12.5,
+			Amount:      87.5,
+			TaxAmount:   16.63,
+			TotalAmount: 104.13,
+		},
+	}
+	quote.Amount, quote.TaxAmount, quote.TotalAmount = 287.5, 54.63, 342.13
+
+	var pdf bytes.Buffer
+
+	// act
+	err := newTestRenderer(t).Render(context.Background(), &pdf, quote)
+
+	// assert
+	assert.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-")))
+	assertGolden(t, "quote_full", pdf.Bytes())
+}
+
+func TestRenderEmpty(t *testing.T) {
+	// arrange
+	quote := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.New())
+	quote.UpdatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var pdf bytes.Buffer
+
+	// act
+	err := newTestRenderer(t).Render(context.Background(), &pdf, quote)
+
+	// assert
+	assert.NoError(t, err)
+	assertGolden(t, "quote_empty", pdf.Bytes())
+}
+
+func TestRenderCatalogUnavailable(t *testing.T) {
+	// arrange
+	renderer, err := document.NewRenderer(document.Config{Currency: "EUR"}, unavailableCatalog{})
+	assert.NoError(t, err)
+
+	quote := types.NewQuote(uuid.New(), uuid.New())
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 1}}
+	var pdf bytes.Buffer
+
+	// act
+	err = renderer.Render(context.Background(), &pdf, quote)
+
+	// assert
+	assert.ErrorIs(t, err, catalog.ErrUnavailable)
+	assert.Zero(t, pdf.Len())
+}
+
+func TestRenderTemplate(t *testing.T) {
+	// arrange
+	path := filepath.Join(t.TempDir(), "quote.tmpl")
+	assert.NoError(t, os.WriteFile(path, []byte(`{{define "title"}}Offer {{.Number}}{{end}}
+{{define "intro"}}Dear customer,{{end}}
+{{define "terms"}}Valid for 14 days.{{end}}
+{{define "footer"}}{{.Company.Name}}{{end}}`), 0o644))
+
+	renderer, err := document.NewRenderer(document.Config{
+		CompanyName:  "Meisterwerk GmbH",
+		Currency:     "EUR",
+		AccentColor:  "#000000",
+		TemplatePath: path,
+	}, testCatalog{})
+	assert.NoError(t, err)
+
+	quote := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.New())
+	var pdf bytes.Buffer
+
+	// act
+	err = renderer.Render(context.Background(), &pdf, quote)
+
+	// assert
+	assert.NoError(t, err)
+	text := extractText(t, pdf.Bytes())
+	assert.Contains(t, text, "Offer 7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d\n")
+	assert.Contains(t, text, "Dear customer,\n")
+	assert.Contains(t, text, "Valid for 14 days.\n")
+}
+
+func TestNewRendererInvalidColor(t *testing.T) {
+	_, err := document.NewRenderer(document.Config{AccentColor: "blue"}, testCatalog{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+const maxAcceptorNameLength = 200
+
+// Accept records the customer's acceptance of the terms for the revision of the draft they read and moves the
+// draft to accepted. An accepted quote can not be changed anymore, it leaves the drafts of the customer and is
+// processed by its ID. Only the customer can accept, staff reading the quote on their behalf can not.
+func (q *Quote) Accept(ctx context.Context, customerUUID uuid.UUID, acceptance types.Acceptance) (*types.Quote, error) {
+	if actor, ok := types.ActorFromContext(ctx); !ok || actor.Type != types.ActorTypeCustomer {
+		return nil, fmt.Errorf("Domain::Quote::Accept : %w", types.ErrQuoteAcceptanceForbidden)
+	}
+
+	var accepted *types.Quote
+	err := q.withLock(ctx, customerUUID, func() error {
+		quote, err := q.LoadDraftByCustomer(ctx, customerUUID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPrecondition(ctx, quote); err != nil {
+			return err
+		}
+
+		if err := q.validateAcceptance(quote, &acceptance); err != nil {
+			return err
+		}
+
+		// the customer accepts what they read, a revision saved in between has to be read again
+		if acceptance.Revision != quote.Revision {
+			return types.ErrQuoteModified
+		}
+
+		if quote.HasPendingApprovals() {
+			return types.ErrQuoteApprovalPending
+		}
+
+		// the negotiation has to be settled, the customer can not accept prices still under discussion
+		if quote.OpenOffer() != nil {
+			return types.ErrOfferPending
+		}
+
+		acceptance.AcceptedAt = time.Now()
+		acceptance.RevisionHash = quote.ContentHash()
+		q.record(ctx, quote, types.QuoteAccepted{Acceptance: acceptance})
+
+		if err := q.save(ctx, quote); err != nil {
+			return err
+		}
+
+		accepted = quote
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::Accept : %w", err)
+	}
+
+	return accepted, nil
+}
+
+// loadProcessable returns the quote ProcessByCustomerID processes: the active draft, or the quote scoped by its ID
+// when it is a draft or an accepted quote.
+func (q *Quote) loadProcessable(ctx context.Context, customerUUID uuid.UUID) (*types.Quote, error) {
+	quoteUUID, ok := types.DraftIDFromContext(ctx)
+	if !ok {
+		return q.LoadDraftByCustomer(ctx, customerUUID)
+	}
+
+	quote, err := q.LoadByID(ctx, customerUUID, quoteUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Status != types.QuoteStatusDraft && quote.Status != types.QuoteStatusAccepted {
+		return nil, types.ErrQuoteUnchangeable
+	}
+
+	return quote, nil
+}
+
+// checkAcceptance requires the quotes of the customer segments configured for it to be accepted, and an accepted
+// quote to be unchanged since its acceptance. The customer segment is only looked up when any segment requires
+// an acceptance.
+func (q *Quote) checkAcceptance(ctx context.Context, quote *types.Quote) error {
+	if quote.Acceptance != nil {
+		if quote.Acceptance.RevisionHash != quote.ContentHash() {
+			return types.ErrQuoteAcceptanceRequired
+		}
+
+		return nil
+	}
+
+	if len(q.config.AcceptanceSegments) == 0 {
+		return nil
+	}
+
+	segment, err := q.customers.GetSegment(ctx, quote.CustomerID)
+	if err != nil {
+		return err
+	}
+
+	if slices.Contains(q.config.AcceptanceSegments, segment) {
+		return types.ErrQuoteAcceptanceRequired
+	}
+
+	return nil
+}
+
+// validateAcceptance takes any terms version when no current version is configured.
+func (q *Quote) validateAcceptance(quote *types.Quote, acceptance *types.Acceptance) error {
+	validation := &types.ValidationError{}
+
+	acceptance.Name = strings.TrimSpace(acceptance.Name)
+	if acceptance.Name == "" {
+		validation.Add("name", "name is required")
+	}
+	if utf8.RuneCountInString(acceptance.Name) > maxAcceptorNameLength {
+		validation.Add("name", fmt.Sprintf("name can not be longer than %d characters", maxAcceptorNameLength))
+	}
+
+	acceptance.Email = strings.TrimSpace(acceptance.Email)
+	if address, err := mail.ParseAddress(acceptance.Email); err != nil || address.Address != acceptance.Email {
+		validation.Add("email", "email must be a valid address")
+	}
+
+	switch {
+	case acceptance.TermsVersion == "":
+		validation.Add("terms_version", "terms version is required")
+	case q.config.TermsVersion != "" && acceptance.TermsVersion != q.config.TermsVersion:
+		validation.Add("terms_version", fmt.Sprintf("terms version must be the current version %s", q.config.TermsVersion))
+	}
+
+	if len(quote.Products) == 0 {
+		validation.Add("products", "quote has no products to accept")
+	}
+
+	return validation.Err()
+}
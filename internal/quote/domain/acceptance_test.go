@@ -0,0 +1,296 @@
+package domain_test
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestAcceptedQuote(customerUUID uuid.UUID) *types.Quote {
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 3
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 2, Amount: 100, TaxAmount: 10, TotalAmount: 110}}
+	quote.Record(types.Event{Data: types.QuoteAccepted{Acceptance: types.Acceptance{
+		Name:         "Jane Doe",
+		Email:        "jane@example.com",
+		Revision:     3,
+		RevisionHash: quote.ContentHash(),
+		TermsVersion: "2026-01",
+	}}})
+
+	return quote
+}
+
+func newTestCustomerContext(customerUUID uuid.UUID) context.Context {
+	return types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeCustomer, ID: customerUUID.String()})
+}
+
+func TestQuoteAccept(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	ctx := newTestCustomerContext(customerUUID)
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 3
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 2, Amount: 100, TaxAmount: 10, TotalAmount: 110}}
+	hash := quote.ContentHash()
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	var saved *types.Quote
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			assert.Len(t, q.Changes, 1)
+			assert.Equal(t, types.EventQuoteAccepted, q.Changes[0].Data.EventType())
+			saved = q
+			return nil
+		})
+
+	// act
+	accepted, err := tc.service.Accept(ctx, customerUUID, types.Acceptance{
+		Name:         " Jane Doe ",
+		Email:        "jane@example.com",
+		IP:           "203.0.113.7",
+		UserAgent:    "test",
+		Revision:     3,
+		TermsVersion: "2026-01",
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Same(t, saved, accepted)
+	assert.Equal(t, types.QuoteStatusAccepted, accepted.Status)
+	assert.Equal(t, 4, accepted.Revision)
+	assert.Equal(t, "Jane Doe", accepted.Acceptance.Name)
+	assert.Equal(t, "jane@example.com", accepted.Acceptance.Email)
+	assert.Equal(t, "203.0.113.7", accepted.Acceptance.IP)
+	assert.Equal(t, 3, accepted.Acceptance.Revision)
+	assert.Equal(t, "2026-01", accepted.Acceptance.TermsVersion)
+	assert.Equal(t, hash, accepted.Acceptance.RevisionHash)
+	assert.Equal(t, hash, accepted.ContentHash())
+	assert.WithinDuration(t, time.Now(), accepted.Acceptance.AcceptedAt, time.Minute)
+}
+
+func TestQuoteAcceptInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	accepted, err := tc.service.Accept(newTestCustomerContext(customerUUID), customerUUID, types.Acceptance{
+		Name:         " ",
+		Email:        "Jane <jane@example.com>",
+		TermsVersion: "2025-06",
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, accepted)
+	assert.Equal(t, []types.FieldError{
+		{Field: "name", Message: "name is required"},
+		{Field: "email", Message: "email must be a valid address"},
+		{Field: "terms_version", Message: "terms version must be the current version 2026-01"},
+		{Field: "products", Message: "quote has no products to accept"},
+	}, validationError.Fields)
+}
+
+func TestQuoteAcceptModified(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Revision = 3
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 2}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	accepted, err := tc.service.Accept(newTestCustomerContext(customerUUID), customerUUID, types.Acceptance{
+		Name:         "Jane Doe",
+		Email:        "jane@example.com",
+		Revision:     2,
+		TermsVersion: "2026-01",
+	})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteModified)
+	assert.Nil(t, accepted)
+}
+
+func TestQuoteAcceptAccepted(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := newTestAcceptedQuote(customerUUID)
+	ctx := types.WithDraftID(newTestCustomerContext(customerUUID), quote.UUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	// act
+	accepted, err := tc.service.Accept(ctx, customerUUID, types.Acceptance{
+		Name:         "Jane Doe",
+		Email:        "jane@example.com",
+		Revision:     quote.Revision,
+		TermsVersion: "2026-01",
+	})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteUnchangeable)
+	assert.Nil(t, accepted)
+}
+
+func TestQuoteAcceptStaff(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	ctx := types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeStaff, ID: uuid.NewString()})
+
+	// act
+	accepted, err := tc.service.Accept(ctx, customerUUID, types.Acceptance{
+		Name:         "Jane Doe",
+		Email:        "jane@example.com",
+		Revision:     3,
+		TermsVersion: "2026-01",
+	})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteAcceptanceForbidden)
+	assert.Nil(t, accepted)
+}
+
+func TestQuoteProcessAcceptanceRequired(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 1}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.customers.EXPECT().
+		GetSegment(gomock.Any(), gomock.Eq(customerUUID)).
+		Return("enterprise", nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(context.Background(), customerUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteAcceptanceRequired)
+	assert.Equal(t, types.QuoteStatusDraft, quote.Status)
+}
+
+func TestQuoteProcessAcceptanceNotRequired(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Products = []types.Product{{ProductID: uuid.New(), Quantity: 1}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.customers.EXPECT().
+		GetSegment(gomock.Any(), gomock.Eq(customerUUID)).
+		Return("retail", nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	tc.orderClient.EXPECT().Process(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(context.Background(), customerUUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, types.QuoteStatusDone, quote.Status)
+}
+
+func TestQuoteProcessAccepted(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := newTestAcceptedQuote(customerUUID)
+	ctx := types.WithDraftID(context.Background(), quote.UUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	tc.orderClient.EXPECT().Process(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(ctx, customerUUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, types.QuoteStatusDone, quote.Status)
+	assert.NotNil(t, quote.Acceptance)
+}
+
+func TestQuoteProcessAcceptedChanged(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := newTestAcceptedQuote(customerUUID)
+	quote.Products[0].Quantity = 20
+	ctx := types.WithDraftID(context.Background(), quote.UUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(ctx, customerUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteAcceptanceRequired)
+	assert.Equal(t, types.QuoteStatusAccepted, quote.Status)
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+const maxDraftNameLength = 100
+
+// ListDrafts returns the drafts of the customer, the active draft, which the single quote operations
+// change, first.
+func (q *Quote) ListDrafts(ctx context.Context, customerUUID uuid.UUID) ([]*types.Quote, error) {
+	page, err := q.repository.FindQuotes(ctx, types.QuoteQuery{
+		Filter: types.QuoteFilter{CustomerID: customerUUID, Statuses: []types.QuoteStatus{types.QuoteStatusDraft}},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ListDrafts : %w", err)
+	}
+
+	slices.SortFunc(page.Quotes, types.CompareActivation)
+	return page.Quotes, nil
+}
+
+// CreateDraft starts a new named draft of the customer next to the existing ones and makes it the active draft.
+func (q *Quote) CreateDraft(ctx context.Context, customerUUID uuid.UUID, name string) (*types.Quote, error) {
+	name, err := validateDraftName(name)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::CreateDraft : %w", err)
+	}
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	err = q.withLock(ctx, customerUUID, func() error {
+		q.record(ctx, quote, types.QuoteCreated{
+			CustomerID: quote.CustomerID,
+			CreatedAt:  quote.CreatedAt,
+		})
+		q.record(ctx, quote, types.DraftNamed{Name: name})
+		q.record(ctx, quote, types.DraftActivated{ActivatedAt: time.Now()})
+
+		return q.save(ctx, quote)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::CreateDraft : %w", err)
+	}
+
+	return quote, nil
+}
+
+// RenameDraft names the customer's draft quote.
+func (q *Quote) RenameDraft(ctx context.Context, customerUUID uuid.UUID, name string) error {
+	name, err := validateDraftName(name)
+	if err != nil {
+		return fmt.Errorf("Domain::Quote::RenameDraft : %w", err)
+	}
+
+	err = q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		q.record(ctx, quote, types.DraftNamed{Name: name})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Domain::Quote::RenameDraft : %w", err)
+	}
+
+	return nil
+}
+
+// ActivateDraft makes the customer's draft quote the active draft, so the single quote operations change it.
+func (q *Quote) ActivateDraft(ctx context.Context, customerUUID uuid.UUID) error {
+	err := q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		q.record(ctx, quote, types.DraftActivated{ActivatedAt: time.Now()})
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Domain::Quote::ActivateDraft : %w", err)
+	}
+
+	return nil
+}
+
+// loadDraft returns a draft of the customer by its ID. A quote which is no draft anymore can not be changed.
+func (q *Quote) loadDraft(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error) {
+	quote, err := q.LoadByID(ctx, customerUUID, quoteUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.Status != types.QuoteStatusDraft {
+		return nil, types.ErrQuoteUnchangeable
+	}
+
+	return quote, nil
+}
+
+func validateDraftName(name string) (string, error) {
+	validation := &types.ValidationError{}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		validation.Add("name", "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxDraftNameLength {
+		validation.Add("name", fmt.Sprintf("name can not be longer than %d characters", maxDraftNameLength))
+	}
+
+	return name, validation.Err()
+}
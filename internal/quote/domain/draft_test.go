@@ -0,0 +1,175 @@
+package domain_test
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteListDrafts(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	now := time.Now()
+
+	unnamed := types.NewQuote(uuid.New(), customerUUID)
+	unnamed.UpdatedAt = now
+	kitchen := types.NewQuote(uuid.New(), customerUUID)
+	kitchen.ActivatedAt = now.Add(-2 * time.Hour)
+	bathroom := types.NewQuote(uuid.New(), customerUUID)
+	bathroom.ActivatedAt = now.Add(-time.Hour)
+
+	tc.repository.EXPECT().
+		FindQuotes(gomock.Any(), gomock.Eq(types.QuoteQuery{
+			Filter: types.QuoteFilter{CustomerID: customerUUID, Statuses: []types.QuoteStatus{types.QuoteStatusDraft}},
+		})).
+		Return(&types.QuotePage{Quotes: []*types.Quote{unnamed, kitchen, bathroom}}, nil)
+
+	// act
+	drafts, err := tc.service.ListDrafts(context.Background(), customerUUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []*types.Quote{bathroom, kitchen, unnamed}, drafts)
+}
+
+func TestQuoteCreateDraft(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	tc.repository.EXPECT().
+		NextQuoteNumber(gomock.Any(), gomock.Eq("meisterwerk"), gomock.Any()).
+		Return(7, nil)
+
+	var saved *types.Quote
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			saved = q
+			return nil
+		})
+
+	// act
+	quote, err := tc.service.CreateDraft(context.Background(), customerUUID, "  Kitchen renovation ")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Same(t, saved, quote)
+	assert.Equal(t, customerUUID, quote.CustomerID)
+	assert.Equal(t, types.QuoteStatusDraft, quote.Status)
+	assert.Equal(t, "Kitchen renovation", quote.Name)
+	assert.False(t, quote.ActivatedAt.IsZero())
+	assert.NotEmpty(t, quote.Number)
+}
+
+func TestQuoteCreateDraftInvalid(t *testing.T) {
+	for name, draftName := range map[string]string{
+		"blank":    "  ",
+		"too long": strings.Repeat("a", 101),
+	} {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+
+			// act
+			quote, err := tc.service.CreateDraft(context.Background(), uuid.New(), draftName)
+
+			// assert
+			assert.ErrorIs(t, err, types.ErrQuoteValidation)
+			assert.Nil(t, quote)
+		})
+	}
+}
+
+func TestQuoteLoadDraftByCustomerScoped(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	expected := types.NewQuote(uuid.New(), customerUUID)
+	ctx := types.WithDraftID(context.Background(), expected.UUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(expected.UUID)).
+		Return(expected, nil)
+
+	// act
+	actual, err := tc.service.LoadDraftByCustomer(ctx, customerUUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestQuoteLoadDraftByCustomerScopedProcessed(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Status = types.QuoteStatusDone
+	ctx := types.WithDraftID(context.Background(), quote.UUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	// act
+	actual, err := tc.service.LoadDraftByCustomer(ctx, customerUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteUnchangeable)
+	assert.Nil(t, actual)
+}
+
+func TestQuoteActivateDraft(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	ctx := types.WithDraftID(context.Background(), quote.UUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	var saved *types.Quote
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			saved = q
+			return nil
+		})
+
+	// act
+	err := tc.service.ActivateDraft(ctx, customerUUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, quote.UUID, saved.UUID)
+	assert.False(t, saved.ActivatedAt.IsZero())
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"app/internal/quote/types"
+)
+
+// ScanQuotes calls fn with every quote of the filter, ordered by their last change, so a large set of quotes
+// is processed one quote at a time. An error of fn stops the scan and is returned.
+func (q *Quote) ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error {
+	if err := q.repository.ScanQuotes(ctx, filter, fn); err != nil {
+		return fmt.Errorf("Domain::Quote::ScanQuotes : %w", err)
+	}
+
+	return nil
+}
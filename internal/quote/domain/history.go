@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+// ListQuotes returns a page of the customer's quotes in any status, e.g. the processed ones.
+// The query is always restricted to the customer, whatever customer its filter names.
+func (q *Quote) ListQuotes(ctx context.Context, customerUUID uuid.UUID, query types.QuoteQuery) (*types.QuotePage, error) {
+	query.Filter.CustomerID = customerUUID
+
+	page, err := q.repository.FindQuotes(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ListQuotes : %w", err)
+	}
+
+	return page, nil
+}
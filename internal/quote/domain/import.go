@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+// ImportProducts adds the imported lines to the customer's draft quote in one change. Lines given by SKU are
+// resolved through the catalog first. When any line is invalid no line is added and the returned validation
+// error lists the fields of every invalid line by its row, e.g. `rows.4.sku`. The results are indexed like lines.
+func (q *Quote) ImportProducts(ctx context.Context, customerUUID uuid.UUID, lines []types.ImportLine) ([]types.ProductOperationResult, error) {
+	validation := &types.ValidationError{}
+
+	operations := make([]types.ProductOperation, 0, len(lines))
+	indexes := make([]int, 0, len(lines))
+	for i, line := range lines {
+		productID := line.ProductID
+		if productID == uuid.Nil && line.SKU != "" {
+			product, err := q.catalog.GetProductBySKU(ctx, line.SKU)
+			if errors.Is(err, catalog.ErrProductNotFound) {
+				validation.Add(importField(line.Row)+".sku", "sku is not in the catalog")
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("Domain::Quote::ImportProducts : %w", err)
+			}
+			productID = product.ProductID
+		}
+
+		operations = append(operations, types.ProductOperation{
+			Type:      types.ProductOperationAdd,
+			ProductID: productID,
+			Quantity:  line.Quantity,
+		})
+		indexes = append(indexes, i)
+	}
+
+	results, err := q.applyProductOperations(ctx, customerUUID, operations, func(index int) string {
+		return importField(lines[indexes[index]].Row)
+	}, validation)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ImportProducts : %w", err)
+	}
+
+	for i := range results {
+		results[i].Index = indexes[results[i].Index]
+	}
+
+	return results, nil
+}
+
+func importField(row int) string {
+	return fmt.Sprintf("rows.%d", row)
+}
@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+func TestQuoteImportProducts(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID, skuProductUUID := uuid.New(), uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductBySKU(gomock.Any(), gomock.Eq("BOLT-M8")).
+		Return(&catalog.Product{ProductID: skuProductUUID, SKU: "BOLT-M8"}, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		Return(&catalog.Product{Price: 10, TaxRateID: "standard"}, nil).
+		Times(4)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Any()).
+		Return(0.0, nil).
+		Times(2)
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	// act
+	results, err := tc.service.ImportProducts(context.Background(), customerUUID, []types.ImportLine{
+		{Row: 2, ProductID: productUUID, Quantity: 2},
+		{Row: 3, SKU: "BOLT-M8", Quantity: 5},
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ProductOperationResult{
+		{Index: 0, Type: types.ProductOperationAdd, ProductID: productUUID, Quantity: 2},
+		{Index: 1, Type: types.ProductOperationAdd, ProductID: skuProductUUID, Quantity: 5},
+	}, results)
+	assert.Len(t, quote.Products, 2)
+	assert.Equal(t, 70.0, quote.Amount)
+}
+
+func TestQuoteImportProductsInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+
+	tc.catalogClient.EXPECT().
+		GetProductBySKU(gomock.Any(), gomock.Eq("UNKNOWN")).
+		Return(nil, fmt.Errorf("catalog : %w", catalog.ErrProductNotFound))
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		Return(&catalog.Product{Price: 10, TaxRateID: "standard"}, nil).
+		Times(2)
+
+	// act
+	results, err := tc.service.ImportProducts(context.Background(), customerUUID, []types.ImportLine{
+		{Row: 2, ProductID: uuid.New(), Quantity: 1},
+		{Row: 3, SKU: "UNKNOWN", Quantity: 1},
+		{Row: 5, ProductID: uuid.New(), Quantity: 0},
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, results)
+	assert.Equal(t, []types.FieldError{
+		{Field: "rows.3.sku", Message: "sku is not in the catalog"},
+		{Field: "rows.5.qty", Message: "quantity must be at least 1"},
+	}, validationError.Fields)
+}
@@ -10,6 +10,8 @@
 package mock_domain
 
 import (
+	catalog "app/internal/catalog"
+	share "app/internal/quote/share"
 	types "app/internal/quote/types"
 	context "context"
 	reflect "reflect"
@@ -18,6 +20,44 @@ import (
 	gomock "go.uber.org/mock/gomock"
 )
 
+// MockorderClient is a mock of orderClient interface.
+type MockorderClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockorderClientMockRecorder
+	isgomock struct{}
+}
+
+// MockorderClientMockRecorder is the mock recorder for MockorderClient.
+type MockorderClientMockRecorder struct {
+	mock *MockorderClient
+}
+
+// NewMockorderClient creates a new mock instance.
+func NewMockorderClient(ctrl *gomock.Controller) *MockorderClient {
+	mock := &MockorderClient{ctrl: ctrl}
+	mock.recorder = &MockorderClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockorderClient) EXPECT() *MockorderClientMockRecorder {
+	return m.recorder
+}
+
+// Process mocks base method.
+func (m *MockorderClient) Process(ctx context.Context, quote *types.Quote) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Process", ctx, quote)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Process indicates an expected call of Process.
+func (mr *MockorderClientMockRecorder) Process(ctx, quote any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Process", reflect.TypeOf((*MockorderClient)(nil).Process), ctx, quote)
+}
+
 // MockcatalogClient is a mock of catalogClient interface.
 type MockcatalogClient struct {
 	ctrl     *gomock.Controller
@@ -43,10 +83,10 @@ func (m *MockcatalogClient) EXPECT() *MockcatalogClientMockRecorder {
 }
 
 // GetProductByID mocks base method.
-func (m *MockcatalogClient) GetProductByID(ctx context.Context, productID uuid.UUID) (*types.CatalogProduct, error) {
+func (m *MockcatalogClient) GetProductByID(ctx context.Context, productID uuid.UUID) (*catalog.Product, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetProductByID", ctx, productID)
-	ret0, _ := ret[0].(*types.CatalogProduct)
+	ret0, _ := ret[0].(*catalog.Product)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -57,6 +97,21 @@ func (mr *MockcatalogClientMockRecorder) GetProductByID(ctx, productID any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductByID", reflect.TypeOf((*MockcatalogClient)(nil).GetProductByID), ctx, productID)
 }
 
+// GetProductBySKU mocks base method.
+func (m *MockcatalogClient) GetProductBySKU(ctx context.Context, sku string) (*catalog.Product, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProductBySKU", ctx, sku)
+	ret0, _ := ret[0].(*catalog.Product)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProductBySKU indicates an expected call of GetProductBySKU.
+func (mr *MockcatalogClientMockRecorder) GetProductBySKU(ctx, sku any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProductBySKU", reflect.TypeOf((*MockcatalogClient)(nil).GetProductBySKU), ctx, sku)
+}
+
 // MocktaxClient is a mock of taxClient interface.
 type MocktaxClient struct {
 	ctrl     *gomock.Controller
@@ -96,6 +151,45 @@ func (mr *MocktaxClientMockRecorder) CalculateTaxes(ctx, taxRateID, amount any)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CalculateTaxes", reflect.TypeOf((*MocktaxClient)(nil).CalculateTaxes), ctx, taxRateID, amount)
 }
 
+// MockcustomerClient is a mock of customerClient interface.
+type MockcustomerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockcustomerClientMockRecorder
+	isgomock struct{}
+}
+
+// MockcustomerClientMockRecorder is the mock recorder for MockcustomerClient.
+type MockcustomerClientMockRecorder struct {
+	mock *MockcustomerClient
+}
+
+// NewMockcustomerClient creates a new mock instance.
+func NewMockcustomerClient(ctrl *gomock.Controller) *MockcustomerClient {
+	mock := &MockcustomerClient{ctrl: ctrl}
+	mock.recorder = &MockcustomerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockcustomerClient) EXPECT() *MockcustomerClientMockRecorder {
+	return m.recorder
+}
+
+// GetSegment mocks base method.
+func (m *MockcustomerClient) GetSegment(ctx context.Context, customerUUID uuid.UUID) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegment", ctx, customerUUID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegment indicates an expected call of GetSegment.
+func (mr *MockcustomerClientMockRecorder) GetSegment(ctx, customerUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegment", reflect.TypeOf((*MockcustomerClient)(nil).GetSegment), ctx, customerUUID)
+}
+
 // MockquoteRepository is a mock of quoteRepository interface.
 type MockquoteRepository struct {
 	ctrl     *gomock.Controller
@@ -135,6 +229,96 @@ func (mr *MockquoteRepositoryMockRecorder) FindByCustomerAndStatus(ctx, customer
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByCustomerAndStatus", reflect.TypeOf((*MockquoteRepository)(nil).FindByCustomerAndStatus), ctx, customerUUID, status)
 }
 
+// FindByID mocks base method.
+func (m *MockquoteRepository) FindByID(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", ctx, quoteUUID)
+	ret0, _ := ret[0].(*types.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockquoteRepositoryMockRecorder) FindByID(ctx, quoteUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockquoteRepository)(nil).FindByID), ctx, quoteUUID)
+}
+
+// FindByNumber mocks base method.
+func (m *MockquoteRepository) FindByNumber(ctx context.Context, number string) (*types.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByNumber", ctx, number)
+	ret0, _ := ret[0].(*types.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByNumber indicates an expected call of FindByNumber.
+func (mr *MockquoteRepositoryMockRecorder) FindByNumber(ctx, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByNumber", reflect.TypeOf((*MockquoteRepository)(nil).FindByNumber), ctx, number)
+}
+
+// FindQuotes mocks base method.
+func (m *MockquoteRepository) FindQuotes(ctx context.Context, query types.QuoteQuery) (*types.QuotePage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindQuotes", ctx, query)
+	ret0, _ := ret[0].(*types.QuotePage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindQuotes indicates an expected call of FindQuotes.
+func (mr *MockquoteRepositoryMockRecorder) FindQuotes(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindQuotes", reflect.TypeOf((*MockquoteRepository)(nil).FindQuotes), ctx, query)
+}
+
+// FindRevision mocks base method.
+func (m *MockquoteRepository) FindRevision(ctx context.Context, quoteUUID uuid.UUID, number int) (*types.QuoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevision", ctx, quoteUUID, number)
+	ret0, _ := ret[0].(*types.QuoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevision indicates an expected call of FindRevision.
+func (mr *MockquoteRepositoryMockRecorder) FindRevision(ctx, quoteUUID, number any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevision", reflect.TypeOf((*MockquoteRepository)(nil).FindRevision), ctx, quoteUUID, number)
+}
+
+// FindRevisions mocks base method.
+func (m *MockquoteRepository) FindRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRevisions", ctx, quoteUUID)
+	ret0, _ := ret[0].([]types.QuoteRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRevisions indicates an expected call of FindRevisions.
+func (mr *MockquoteRepositoryMockRecorder) FindRevisions(ctx, quoteUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRevisions", reflect.TypeOf((*MockquoteRepository)(nil).FindRevisions), ctx, quoteUUID)
+}
+
+// NextQuoteNumber mocks base method.
+func (m *MockquoteRepository) NextQuoteNumber(ctx context.Context, tenant string, year int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NextQuoteNumber", ctx, tenant, year)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// NextQuoteNumber indicates an expected call of NextQuoteNumber.
+func (mr *MockquoteRepositoryMockRecorder) NextQuoteNumber(ctx, tenant, year any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NextQuoteNumber", reflect.TypeOf((*MockquoteRepository)(nil).NextQuoteNumber), ctx, tenant, year)
+}
+
 // Save mocks base method.
 func (m *MockquoteRepository) Save(ctx context.Context, quote *types.Quote) error {
 	m.ctrl.T.Helper()
@@ -148,3 +332,250 @@ func (mr *MockquoteRepositoryMockRecorder) Save(ctx, quote any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockquoteRepository)(nil).Save), ctx, quote)
 }
+
+// ScanQuotes mocks base method.
+func (m *MockquoteRepository) ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(*types.Quote) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ScanQuotes", ctx, filter, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ScanQuotes indicates an expected call of ScanQuotes.
+func (mr *MockquoteRepositoryMockRecorder) ScanQuotes(ctx, filter, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ScanQuotes", reflect.TypeOf((*MockquoteRepository)(nil).ScanQuotes), ctx, filter, fn)
+}
+
+// MocktemplateRepository is a mock of templateRepository interface.
+type MocktemplateRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MocktemplateRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MocktemplateRepositoryMockRecorder is the mock recorder for MocktemplateRepository.
+type MocktemplateRepositoryMockRecorder struct {
+	mock *MocktemplateRepository
+}
+
+// NewMocktemplateRepository creates a new mock instance.
+func NewMocktemplateRepository(ctrl *gomock.Controller) *MocktemplateRepository {
+	mock := &MocktemplateRepository{ctrl: ctrl}
+	mock.recorder = &MocktemplateRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MocktemplateRepository) EXPECT() *MocktemplateRepositoryMockRecorder {
+	return m.recorder
+}
+
+// DeleteTemplate mocks base method.
+func (m *MocktemplateRepository) DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTemplate", ctx, templateUUID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTemplate indicates an expected call of DeleteTemplate.
+func (mr *MocktemplateRepositoryMockRecorder) DeleteTemplate(ctx, templateUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTemplate", reflect.TypeOf((*MocktemplateRepository)(nil).DeleteTemplate), ctx, templateUUID)
+}
+
+// FindTemplateByID mocks base method.
+func (m *MocktemplateRepository) FindTemplateByID(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTemplateByID", ctx, templateUUID)
+	ret0, _ := ret[0].(*types.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTemplateByID indicates an expected call of FindTemplateByID.
+func (mr *MocktemplateRepositoryMockRecorder) FindTemplateByID(ctx, templateUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTemplateByID", reflect.TypeOf((*MocktemplateRepository)(nil).FindTemplateByID), ctx, templateUUID)
+}
+
+// FindTemplates mocks base method.
+func (m *MocktemplateRepository) FindTemplates(ctx context.Context) ([]*types.Template, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindTemplates", ctx)
+	ret0, _ := ret[0].([]*types.Template)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindTemplates indicates an expected call of FindTemplates.
+func (mr *MocktemplateRepositoryMockRecorder) FindTemplates(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindTemplates", reflect.TypeOf((*MocktemplateRepository)(nil).FindTemplates), ctx)
+}
+
+// SaveTemplate mocks base method.
+func (m *MocktemplateRepository) SaveTemplate(ctx context.Context, template *types.Template) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTemplate", ctx, template)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTemplate indicates an expected call of SaveTemplate.
+func (mr *MocktemplateRepositoryMockRecorder) SaveTemplate(ctx, template any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTemplate", reflect.TypeOf((*MocktemplateRepository)(nil).SaveTemplate), ctx, template)
+}
+
+// MockshareRepository is a mock of shareRepository interface.
+type MockshareRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockshareRepositoryMockRecorder is the mock recorder for MockshareRepository.
+type MockshareRepositoryMockRecorder struct {
+	mock *MockshareRepository
+}
+
+// NewMockshareRepository creates a new mock instance.
+func NewMockshareRepository(ctrl *gomock.Controller) *MockshareRepository {
+	mock := &MockshareRepository{ctrl: ctrl}
+	mock.recorder = &MockshareRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareRepository) EXPECT() *MockshareRepositoryMockRecorder {
+	return m.recorder
+}
+
+// FindShareAccesses mocks base method.
+func (m *MockshareRepository) FindShareAccesses(ctx context.Context, shareUUID uuid.UUID) ([]types.ShareAccess, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShareAccesses", ctx, shareUUID)
+	ret0, _ := ret[0].([]types.ShareAccess)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShareAccesses indicates an expected call of FindShareAccesses.
+func (mr *MockshareRepositoryMockRecorder) FindShareAccesses(ctx, shareUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShareAccesses", reflect.TypeOf((*MockshareRepository)(nil).FindShareAccesses), ctx, shareUUID)
+}
+
+// FindShareByID mocks base method.
+func (m *MockshareRepository) FindShareByID(ctx context.Context, shareUUID uuid.UUID) (*types.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShareByID", ctx, shareUUID)
+	ret0, _ := ret[0].(*types.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShareByID indicates an expected call of FindShareByID.
+func (mr *MockshareRepositoryMockRecorder) FindShareByID(ctx, shareUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShareByID", reflect.TypeOf((*MockshareRepository)(nil).FindShareByID), ctx, shareUUID)
+}
+
+// FindShares mocks base method.
+func (m *MockshareRepository) FindShares(ctx context.Context, quoteUUID uuid.UUID) ([]*types.Share, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindShares", ctx, quoteUUID)
+	ret0, _ := ret[0].([]*types.Share)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindShares indicates an expected call of FindShares.
+func (mr *MockshareRepositoryMockRecorder) FindShares(ctx, quoteUUID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindShares", reflect.TypeOf((*MockshareRepository)(nil).FindShares), ctx, quoteUUID)
+}
+
+// RecordShareAccess mocks base method.
+func (m *MockshareRepository) RecordShareAccess(ctx context.Context, access types.ShareAccess) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RecordShareAccess", ctx, access)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RecordShareAccess indicates an expected call of RecordShareAccess.
+func (mr *MockshareRepositoryMockRecorder) RecordShareAccess(ctx, access any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RecordShareAccess", reflect.TypeOf((*MockshareRepository)(nil).RecordShareAccess), ctx, access)
+}
+
+// SaveShare mocks base method.
+func (m *MockshareRepository) SaveShare(ctx context.Context, share *types.Share) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveShare", ctx, share)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveShare indicates an expected call of SaveShare.
+func (mr *MockshareRepositoryMockRecorder) SaveShare(ctx, share any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveShare", reflect.TypeOf((*MockshareRepository)(nil).SaveShare), ctx, share)
+}
+
+// MockshareSigner is a mock of shareSigner interface.
+type MockshareSigner struct {
+	ctrl     *gomock.Controller
+	recorder *MockshareSignerMockRecorder
+	isgomock struct{}
+}
+
+// MockshareSignerMockRecorder is the mock recorder for MockshareSigner.
+type MockshareSignerMockRecorder struct {
+	mock *MockshareSigner
+}
+
+// NewMockshareSigner creates a new mock instance.
+func NewMockshareSigner(ctrl *gomock.Controller) *MockshareSigner {
+	mock := &MockshareSigner{ctrl: ctrl}
+	mock.recorder = &MockshareSignerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockshareSigner) EXPECT() *MockshareSignerMockRecorder {
+	return m.recorder
+}
+
+// Sign mocks base method.
+func (m *MockshareSigner) Sign(claims share.Claims) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sign", claims)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sign indicates an expected call of Sign.
+func (mr *MockshareSignerMockRecorder) Sign(claims any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sign", reflect.TypeOf((*MockshareSigner)(nil).Sign), claims)
+}
+
+// Verify mocks base method.
+func (m *MockshareSigner) Verify(token string) (*share.Claims, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Verify", token)
+	ret0, _ := ret[0].(*share.Claims)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Verify indicates an expected call of Verify.
+func (mr *MockshareSignerMockRecorder) Verify(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockshareSigner)(nil).Verify), token)
+}
@@ -0,0 +1,177 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+const maxOfferNoteLength = 1000
+
+// ProposeOffer adds an offer of line prices to the negotiation of the customer's draft quote. An open offer of the
+// other side is countered by it, while the offer of the side itself is open it has to wait for an answer.
+// The offer is saved as a revision of the draft, the prices only apply once the other side accepts them.
+func (q *Quote) ProposeOffer(ctx context.Context, customerUUID uuid.UUID, create types.OfferCreate) error {
+	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		if err := validateOfferCreate(quote, &create); err != nil {
+			return fmt.Errorf("Domain::Quote::ProposeOffer : %w", err)
+		}
+
+		side := offerSide(ctx)
+		if open := quote.OpenOffer(); open != nil && open.Side == side {
+			return fmt.Errorf("Domain::Quote::ProposeOffer : %w", types.ErrOfferPending)
+		}
+
+		if err := checkOfferDiscount(ctx, side, create.Lines); err != nil {
+			return fmt.Errorf("Domain::Quote::ProposeOffer : %w", err)
+		}
+
+		q.record(ctx, quote, types.OfferProposed{Offer: types.Offer{
+			Number:     len(quote.Offers) + 1,
+			Side:       side,
+			Lines:      create.Lines,
+			Note:       create.Note,
+			Status:     types.OfferStatusOpen,
+			Revision:   quote.Revision + 1,
+			ProposedBy: actorFromContext(ctx),
+			ProposedAt: time.Now(),
+		}})
+
+		return nil
+	})
+}
+
+// AcceptOffer agrees to the open offer of the other side, its prices become the pricing of the named lines
+// which refresh uses until the quote is processed. Like a price override, an agreed price reducing the catalog
+// price above the approval threshold waits for a manager before the quote is processed.
+func (q *Quote) AcceptOffer(ctx context.Context, customerUUID uuid.UUID, number int) error {
+	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		offer, err := findOffer(quote, number)
+		if err != nil {
+			return fmt.Errorf("Domain::Quote::AcceptOffer : %w", err)
+		}
+
+		if offer.Status != types.OfferStatusOpen {
+			return fmt.Errorf("Domain::Quote::AcceptOffer : %w", types.ErrOfferNotOpen)
+		}
+		if offer.Side == offerSide(ctx) {
+			return fmt.Errorf("Domain::Quote::AcceptOffer : %w", types.ErrOfferPending)
+		}
+
+		// accepting the discount of the customer grants it like offering one
+		if err := checkOfferDiscount(ctx, offerSide(ctx), offer.Lines); err != nil {
+			return fmt.Errorf("Domain::Quote::AcceptOffer : %w", err)
+		}
+
+		pending, err := q.pendingOfferLines(ctx, offer)
+		if err != nil {
+			return fmt.Errorf("Domain::Quote::AcceptOffer : %w", err)
+		}
+
+		q.record(ctx, quote, types.OfferAccepted{
+			Number:     number,
+			AcceptedBy: actorFromContext(ctx),
+			AcceptedAt: time.Now(),
+			Pending:    pending,
+		})
+
+		if err := q.refresh(ctx, quote); err != nil {
+			return fmt.Errorf("Domain::Quote::AcceptOffer : %w", err)
+		}
+
+		return nil
+	})
+}
+
+// pendingOfferLines returns the products of the offer whose price, after its discount, reduces the catalog
+// price above the approval threshold.
+func (q *Quote) pendingOfferLines(ctx context.Context, offer *types.Offer) ([]uuid.UUID, error) {
+	var pending []uuid.UUID
+	for _, line := range offer.Lines {
+		productInfo, err := q.catalog.GetProductByID(ctx, line.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("Domain::Quote::pendingOfferLines : %w", err)
+		}
+
+		if q.needsApproval(productInfo.Price, line.Price*(1-line.Discount/100)) {
+			pending = append(pending, line.ProductID)
+		}
+	}
+
+	return pending, nil
+}
+
+// checkOfferDiscount requires the discount permission from the sales side agreeing to lines with a discount, so
+// the negotiation does not grant what the discount route denies. The customer can always ask for a discount.
+func checkOfferDiscount(ctx context.Context, side types.OfferSide, lines []types.OfferLine) error {
+	if side != types.OfferSideSales || types.HasDiscountPermission(ctx) {
+		return nil
+	}
+
+	for _, line := range lines {
+		if line.Discount > 0 {
+			return types.ErrOfferDiscountForbidden
+		}
+	}
+
+	return nil
+}
+
+// offerSide returns the side of the negotiation the actor of the context speaks for, agents and staff
+// negotiate for sales.
+func offerSide(ctx context.Context) types.OfferSide {
+	actor, ok := types.ActorFromContext(ctx)
+	if ok && (actor.Type == types.ActorTypeAgent || actor.Type == types.ActorTypeStaff) {
+		return types.OfferSideSales
+	}
+
+	return types.OfferSideCustomer
+}
+
+func findOffer(quote *types.Quote, number int) (*types.Offer, error) {
+	for i := range quote.Offers {
+		if quote.Offers[i].Number == number {
+			return &quote.Offers[i], nil
+		}
+	}
+
+	return nil, types.ErrOfferNotFound
+}
+
+// validateOfferCreate accepts lines of products on the quote only, every product at most once.
+func validateOfferCreate(quote *types.Quote, create *types.OfferCreate) error {
+	validation := &types.ValidationError{}
+
+	if len(create.Lines) == 0 {
+		validation.Add("lines", "offer needs at least one line")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(create.Lines))
+	for i, line := range create.Lines {
+		field := fmt.Sprintf("lines.%d", i)
+		if _, err := findProduct(quote, line.ProductID); err != nil {
+			validation.Add(field+".product_id", "product is not on the quote")
+		} else if seen[line.ProductID] {
+			validation.Add(field+".product_id", "product is already offered")
+		}
+		seen[line.ProductID] = true
+
+		if line.Price < 0 {
+			validation.Add(field+".price", "price can not be negative")
+		}
+		if line.Discount < 0 || line.Discount > 100 {
+			validation.Add(field+".discount_percent", "discount must be between 0 and 100")
+		}
+	}
+
+	if utf8.RuneCountInString(create.Note) > maxOfferNoteLength {
+		validation.Add("note", fmt.Sprintf("note can not be longer than %d characters", maxOfferNoteLength))
+	}
+
+	return validation.Err()
+}
@@ -0,0 +1,538 @@
+package domain_test
+
+import (
+	"app/internal/catalog"
+	"app/internal/quote/types"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestNegotiatedQuote(customerUUID uuid.UUID, productUUID uuid.UUID, offers ...types.Offer) *types.Quote {
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Revision = 2
+	quote.Products = []types.Product{{ProductID: productUUID, Quantity: 2, Discount: 10}}
+	quote.Offers = offers
+
+	return quote
+}
+
+func TestQuoteProposeOffer(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	actor := types.Actor{Type: types.ActorTypeCustomer, ID: customerUUID.String()}
+	ctx := types.WithActor(context.Background(), actor)
+	quote := newTestNegotiatedQuote(customerUUID, productUUID)
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	var saved *types.Quote
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			saved = q
+			return nil
+		})
+
+	// act
+	err := tc.service.ProposeOffer(ctx, customerUUID, types.OfferCreate{
+		Lines: []types.OfferLine{{ProductID: productUUID, Price: 40, Discount: 5}},
+		Note:  "volume order",
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, saved.Revision)
+	assert.Len(t, saved.Offers, 1)
+	offer := saved.Offers[0]
+	assert.Equal(t, 1, offer.Number)
+	assert.Equal(t, types.OfferSideCustomer, offer.Side)
+	assert.Equal(t, types.OfferStatusOpen, offer.Status)
+	assert.Equal(t, 3, offer.Revision)
+	assert.Equal(t, "volume order", offer.Note)
+	assert.Equal(t, &actor, offer.ProposedBy)
+	assert.Nil(t, saved.AgreedOffer())
+}
+
+func TestQuoteProposeOfferCounter(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	agent := types.Actor{Type: types.ActorTypeAgent, ID: uuid.NewString()}
+	ctx := types.WithActor(context.Background(), agent)
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideCustomer,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 40}},
+		Status: types.OfferStatusOpen,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.ProposeOffer(ctx, customerUUID, types.OfferCreate{
+		Lines: []types.OfferLine{{ProductID: productUUID, Price: 45}},
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, quote.Offers, 2)
+	assert.Equal(t, types.OfferStatusCountered, quote.Offers[0].Status)
+	assert.Equal(t, &agent, quote.Offers[0].DecidedBy)
+	assert.Equal(t, types.OfferSideSales, quote.Offers[1].Side)
+	assert.Equal(t, types.OfferStatusOpen, quote.Offers[1].Status)
+	assert.Same(t, &quote.Offers[1], quote.OpenOffer())
+}
+
+func TestQuoteProposeOfferPending(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideCustomer,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 40}},
+		Status: types.OfferStatusOpen,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.ProposeOffer(context.Background(), customerUUID, types.OfferCreate{
+		Lines: []types.OfferLine{{ProductID: productUUID, Price: 35}},
+	})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrOfferPending)
+	assert.Len(t, quote.Offers, 1)
+}
+
+func TestQuoteProposeOfferDiscountPermission(t *testing.T) {
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		expected error
+	}{
+		{
+			name:     "staff without permission",
+			ctx:      types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeStaff, ID: uuid.NewString()}),
+			expected: types.ErrOfferDiscountForbidden,
+		},
+		{
+			name:     "agent without permission",
+			ctx:      types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeAgent, ID: uuid.NewString()}),
+			expected: types.ErrOfferDiscountForbidden,
+		},
+		{
+			name: "agent with permission",
+			ctx: types.WithDiscountPermission(
+				types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeAgent, ID: uuid.NewString()})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+			customerUUID := uuid.New()
+			productUUID := uuid.New()
+			quote := newTestNegotiatedQuote(customerUUID, productUUID)
+
+			tc.repository.EXPECT().
+				FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+				Return(quote, nil)
+			if tt.expected == nil {
+				tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+			}
+
+			// act
+			err := tc.service.ProposeOffer(tt.ctx, customerUUID, types.OfferCreate{
+				Lines: []types.OfferLine{{ProductID: productUUID, Price: 45, Discount: 5}},
+			})
+
+			// assert
+			if tt.expected != nil {
+				assert.ErrorIs(t, err, tt.expected)
+				assert.Empty(t, quote.Offers)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, quote.Offers, 1)
+		})
+	}
+}
+
+func TestQuoteAcceptOfferDiscountForbidden(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	ctx := types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeStaff, ID: uuid.NewString()})
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideCustomer,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 40, Discount: 10}},
+		Status: types.OfferStatusOpen,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.AcceptOffer(ctx, customerUUID, 1)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrOfferDiscountForbidden)
+	assert.Equal(t, types.OfferStatusOpen, quote.Offers[0].Status)
+	assert.Nil(t, quote.AgreedOffer())
+}
+
+func TestQuoteProposeOfferInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	quote := newTestNegotiatedQuote(customerUUID, productUUID)
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.ProposeOffer(context.Background(), customerUUID, types.OfferCreate{
+		Lines: []types.OfferLine{
+			{ProductID: uuid.New(), Price: 10},
+			{ProductID: productUUID, Price: -1},
+			{ProductID: productUUID, Price: 10, Discount: 120},
+		},
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, []types.FieldError{
+		{Field: "lines.0.product_id", Message: "product is not on the quote"},
+		{Field: "lines.1.price", Message: "price can not be negative"},
+		{Field: "lines.2.product_id", Message: "product is already offered"},
+		{Field: "lines.2.discount_percent", Message: "discount must be between 0 and 100"},
+	}, validationError.Fields)
+}
+
+func TestQuoteAcceptOffer(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	ctx := types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeCustomer, ID: customerUUID.String()})
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideSales,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 40, Discount: 5}},
+		Status: types.OfferStatusOpen,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 50, TaxRateID: "standard"}, nil).
+		Times(2)
+	// 2 x 40 with the agreed 5% instead of the catalog price with the line discount of 10%
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(76.0)).
+		Return(7.6, nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.AcceptOffer(ctx, customerUUID, 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, types.OfferStatusAccepted, quote.Offers[0].Status)
+	assert.Same(t, &quote.Offers[0], quote.AgreedOffer())
+	assert.Nil(t, quote.OpenOffer())
+	assert.Equal(t, 76.0, quote.Amount)
+	assert.Equal(t, 83.6, quote.TotalAmount)
+	// 38 after the discount reduces the catalog price of 50 by 24%, above the threshold of 10%
+	assert.Equal(t, types.ApprovalStatusPending, quote.Offers[0].Lines[0].Approval)
+	assert.True(t, quote.HasPendingApprovals())
+}
+
+func TestQuoteAcceptOfferWithinThreshold(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	ctx := types.WithActor(context.Background(), types.Actor{Type: types.ActorTypeAgent, ID: uuid.NewString()})
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideCustomer,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 46}},
+		Status: types.OfferStatusOpen,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 50, TaxRateID: "standard"}, nil).
+		Times(2)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(92.0)).
+		Return(9.2, nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.AcceptOffer(ctx, customerUUID, 1)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Empty(t, quote.Offers[0].Lines[0].Approval)
+	assert.False(t, quote.HasPendingApprovals())
+}
+
+func TestQuoteDecideAgreedPrice(t *testing.T) {
+	tests := []struct {
+		name   string
+		decide func(tc *testUnitQuote, customerUUID uuid.UUID, productUUID uuid.UUID) error
+		status types.ApprovalStatus
+		amount float64
+	}{
+		{
+			name: "approved",
+			decide: func(tc *testUnitQuote, customerUUID uuid.UUID, productUUID uuid.UUID) error {
+				return tc.service.ApprovePriceOverride(context.Background(), customerUUID, productUUID)
+			},
+			status: types.ApprovalStatusApproved,
+			// 2 x 30 as agreed
+			amount: 60,
+		},
+		{
+			name: "rejected",
+			decide: func(tc *testUnitQuote, customerUUID uuid.UUID, productUUID uuid.UUID) error {
+				return tc.service.RejectPriceOverride(context.Background(), customerUUID, productUUID)
+			},
+			status: types.ApprovalStatusRejected,
+			// 2 x 50 of the catalog with the line discount of 10%
+			amount: 90,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+			customerUUID := uuid.New()
+			productUUID := uuid.New()
+			quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+				Number: 1,
+				Side:   types.OfferSideSales,
+				Lines:  []types.OfferLine{{ProductID: productUUID, Price: 30, Approval: types.ApprovalStatusPending}},
+				Status: types.OfferStatusAccepted,
+			})
+
+			tc.repository.EXPECT().
+				FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+				Return(quote, nil)
+			tc.catalogClient.EXPECT().
+				GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+				Return(&catalog.Product{ProductID: productUUID, Price: 50, TaxRateID: "standard"}, nil)
+			tc.taxClient.EXPECT().
+				CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(tt.amount)).
+				Return(0.0, nil)
+			tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+			// act
+			err := tt.decide(tc, customerUUID, productUUID)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, quote.Offers[0].Lines[0].Approval)
+			assert.False(t, quote.HasPendingApprovals())
+			assert.Equal(t, tt.amount, quote.Amount)
+		})
+	}
+}
+
+func TestQuoteProcessAgreedPricePending(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideSales,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 30, Approval: types.ApprovalStatusPending}},
+		Status: types.OfferStatusAccepted,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(context.Background(), customerUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteApprovalPending)
+	assert.Equal(t, types.QuoteStatusDraft, quote.Status)
+}
+
+func TestQuoteAcceptOfferRejected(t *testing.T) {
+	productUUID := uuid.New()
+	for name, tt := range map[string]struct {
+		offer  types.Offer
+		number int
+		err    error
+	}{
+		"own offer": {
+			offer:  types.Offer{Number: 1, Side: types.OfferSideCustomer, Status: types.OfferStatusOpen},
+			number: 1,
+			err:    types.ErrOfferPending,
+		},
+		"countered offer": {
+			offer:  types.Offer{Number: 1, Side: types.OfferSideSales, Status: types.OfferStatusCountered},
+			number: 1,
+			err:    types.ErrOfferNotOpen,
+		},
+		"unknown offer": {
+			offer:  types.Offer{Number: 1, Side: types.OfferSideSales, Status: types.OfferStatusOpen},
+			number: 2,
+			err:    types.ErrOfferNotFound,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+			customerUUID := uuid.New()
+			quote := newTestNegotiatedQuote(customerUUID, productUUID, tt.offer)
+
+			tc.repository.EXPECT().
+				FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+				Return(quote, nil)
+
+			// act
+			err := tc.service.AcceptOffer(context.Background(), customerUUID, tt.number)
+
+			// assert
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestQuoteRefreshAgreedOffer(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideSales,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 40}},
+		Status: types.OfferStatusAccepted,
+	}, types.Offer{
+		Number: 2,
+		Side:   types.OfferSideCustomer,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 30}},
+		Status: types.OfferStatusCountered,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 50, TaxRateID: "standard"}, nil)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(200.0)).
+		Return(20.0, nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.UpdateProduct(context.Background(), customerUUID, productUUID, &types.ProductUpdate{Quantity: 5})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 200.0, quote.Amount)
+}
+
+func TestQuoteProcessOfferPending(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	quote := newTestNegotiatedQuote(customerUUID, productUUID, types.Offer{
+		Number: 1,
+		Side:   types.OfferSideSales,
+		Lines:  []types.OfferLine{{ProductID: productUUID, Price: 40}},
+		Status: types.OfferStatusOpen,
+	})
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(context.Background(), customerUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrOfferPending)
+	assert.Equal(t, types.QuoteStatusDraft, quote.Status)
+}
@@ -0,0 +1,144 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+// ApplyProductOperations adds, updates and removes lines of the customer's draft quote in one change,
+// the quote is repriced and saved once. Operations are applied in order, so a later operation sees the
+// lines of the former ones. When any operation is invalid no operation is applied and the returned
+// validation error lists the fields of every invalid operation, e.g. `operations.3.qty`.
+func (q *Quote) ApplyProductOperations(ctx context.Context, customerUUID uuid.UUID, operations []types.ProductOperation) ([]types.ProductOperationResult, error) {
+	results, err := q.applyProductOperations(ctx, customerUUID, operations, operationField, &types.ValidationError{})
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ApplyProductOperations : %w", err)
+	}
+
+	return results, nil
+}
+
+// applyProductOperations applies the operations to the draft in one change. The fields of an invalid operation
+// are prefixed by field, validation may already hold errors found before, in both cases nothing is applied.
+func (q *Quote) applyProductOperations(
+	ctx context.Context,
+	customerUUID uuid.UUID,
+	operations []types.ProductOperation,
+	field func(index int) string,
+	validation *types.ValidationError,
+) ([]types.ProductOperationResult, error) {
+	results := make([]types.ProductOperationResult, 0, len(operations))
+
+	err := q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		for i, operation := range operations {
+			if err := q.applyProductOperation(ctx, quote, operation); err != nil {
+				if err := addOperationError(validation, field(i), err); err != nil {
+					return err
+				}
+				continue
+			}
+
+			result := types.ProductOperationResult{
+				Index:     i,
+				Type:      operation.Type,
+				ProductID: operation.ProductID,
+			}
+			if product, err := findProduct(quote, operation.ProductID); err == nil {
+				result.Quantity = product.Quantity
+			}
+			results = append(results, result)
+		}
+
+		if err := validation.Err(); err != nil {
+			return err
+		}
+
+		return q.refresh(ctx, quote)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (q *Quote) applyProductOperation(ctx context.Context, quote *types.Quote, operation types.ProductOperation) error {
+	switch operation.Type {
+	case types.ProductOperationAdd:
+		if err := q.checkCatalogProduct(ctx, quote, operation.ProductID); err != nil {
+			return err
+		}
+
+		return q.addProduct(ctx, quote, &types.ProductAdd{
+			ProductID: operation.ProductID,
+			Quantity:  operation.Quantity,
+		})
+	case types.ProductOperationUpdate:
+		product := &types.ProductUpdate{Quantity: operation.Quantity}
+		if err := q.validateProductUpdate(product); err != nil {
+			return err
+		}
+
+		return q.updateProduct(ctx, quote, operation.ProductID, product)
+	case types.ProductOperationRemove:
+		return q.removeProduct(ctx, quote, operation.ProductID)
+	}
+
+	validation := &types.ValidationError{}
+	validation.Add("op", "operation must be add, update or remove")
+
+	return validation
+}
+
+// checkCatalogProduct rejects a new line of a product which is not in the catalog, so the operation is reported
+// by its index instead of failing the repricing of the whole batch.
+func (q *Quote) checkCatalogProduct(ctx context.Context, quote *types.Quote, productUUID uuid.UUID) error {
+	if productUUID == uuid.Nil {
+		return nil
+	}
+	if _, err := findProduct(quote, productUUID); err == nil {
+		return nil
+	}
+
+	if _, err := q.catalog.GetProductByID(ctx, productUUID); err != nil {
+		if !errors.Is(err, catalog.ErrProductNotFound) {
+			return fmt.Errorf("Domain::Quote::checkCatalogProduct : %w", err)
+		}
+
+		validation := &types.ValidationError{}
+		validation.Add("product_id", "product does not exist in the catalog")
+
+		return validation
+	}
+
+	return nil
+}
+
+func operationField(index int) string {
+	return fmt.Sprintf("operations.%d", index)
+}
+
+// addOperationError records the error of an operation as errors of the fields below field, other errors are returned.
+func addOperationError(validation *types.ValidationError, field string, err error) error {
+	prefix := field + "."
+
+	var operationValidation *types.ValidationError
+	switch {
+	case errors.As(err, &operationValidation):
+		for _, field := range operationValidation.Fields {
+			validation.Add(prefix+field.Field, field.Message)
+		}
+	case errors.Is(err, types.ErrQuoteProductNotFound):
+		validation.Add(prefix+"product_id", "product is not part of the quote")
+	default:
+		return err
+	}
+
+	return nil
+}
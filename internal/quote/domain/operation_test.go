@@ -0,0 +1,163 @@
+package domain_test
+
+import (
+	"app/internal/catalog"
+	"app/internal/quote/types"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestQuoteApplyProductOperations(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	keptUUID, removedUUID, addedUUID := uuid.New(), uuid.New(), uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Products = []types.Product{
+		{ProductID: keptUUID, Quantity: 1},
+		{ProductID: removedUUID, Quantity: 1},
+	}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		Return(&catalog.Product{Price: 10, TaxRateID: "standard"}, nil).
+		Times(3)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Any()).
+		Return(0.0, nil).
+		Times(2)
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		Return(nil).
+		Times(1)
+
+	// act
+	results, err := tc.service.ApplyProductOperations(context.Background(), customerUUID, []types.ProductOperation{
+		{Type: types.ProductOperationRemove, ProductID: removedUUID},
+		{Type: types.ProductOperationAdd, ProductID: addedUUID, Quantity: 2},
+		{Type: types.ProductOperationUpdate, ProductID: keptUUID, Quantity: 3},
+		{Type: types.ProductOperationAdd, ProductID: addedUUID, Quantity: 1},
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ProductOperationResult{
+		{Index: 0, Type: types.ProductOperationRemove, ProductID: removedUUID, Quantity: 0},
+		{Index: 1, Type: types.ProductOperationAdd, ProductID: addedUUID, Quantity: 2},
+		{Index: 2, Type: types.ProductOperationUpdate, ProductID: keptUUID, Quantity: 3},
+		{Index: 3, Type: types.ProductOperationAdd, ProductID: addedUUID, Quantity: 3},
+	}, results)
+	assert.Equal(t, 60.0, quote.Amount)
+}
+
+func TestQuoteApplyProductOperationsInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		Return(&catalog.Product{Price: 10, TaxRateID: "standard"}, nil).
+		Times(2)
+
+	// act
+	results, err := tc.service.ApplyProductOperations(context.Background(), customerUUID, []types.ProductOperation{
+		{Type: types.ProductOperationAdd, ProductID: productUUID, Quantity: 1},
+		{Type: types.ProductOperationUpdate, ProductID: uuid.New(), Quantity: 2},
+		{Type: types.ProductOperationAdd, ProductID: uuid.New(), Quantity: 0},
+		{Type: "replace", ProductID: productUUID},
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, results)
+	assert.Equal(t, []types.FieldError{
+		{Field: "operations.1.product_id", Message: "product is not part of the quote"},
+		{Field: "operations.2.qty", Message: "quantity must be at least 1"},
+		{Field: "operations.3.op", Message: "operation must be add, update or remove"},
+	}, validationError.Fields)
+}
+
+func TestQuoteApplyProductOperationsUnknownProduct(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	knownUUID, unknownUUID := uuid.New(), uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(knownUUID)).
+		Return(&catalog.Product{ProductID: knownUUID, Price: 10, TaxRateID: "standard"}, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(unknownUUID)).
+		Return(nil, fmt.Errorf("catalog : %w", catalog.ErrProductNotFound))
+
+	// act
+	results, err := tc.service.ApplyProductOperations(context.Background(), customerUUID, []types.ProductOperation{
+		{Type: types.ProductOperationAdd, ProductID: knownUUID, Quantity: 1},
+		{Type: types.ProductOperationAdd, ProductID: unknownUUID, Quantity: 1},
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, results)
+	assert.Equal(t, []types.FieldError{
+		{Field: "operations.1.product_id", Message: "product does not exist in the catalog"},
+	}, validationError.Fields)
+}
+
+func TestQuoteApplyProductOperationsCatalogUnavailable(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(types.NewQuote(uuid.New(), customerUUID), nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		Return(nil, catalog.ErrUnavailable)
+
+	// act
+	results, err := tc.service.ApplyProductOperations(context.Background(), customerUUID, []types.ProductOperation{
+		{Type: types.ProductOperationAdd, ProductID: uuid.New(), Quantity: 1},
+	})
+
+	// assert
+	assert.ErrorIs(t, err, catalog.ErrUnavailable)
+	assert.Nil(t, results)
+}
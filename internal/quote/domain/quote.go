@@ -9,9 +9,12 @@ import (
 	"github.com/google/uuid"
 
 	"app/internal/catalog"
+	"app/internal/quote/share"
 	"app/internal/quote/types"
 )
 
+const defaultNumberPrefix = "Q"
+
 type (
 	orderClient interface {
 		Process(ctx context.Context, quote *types.Quote) error
@@ -19,15 +22,72 @@ type (
 
 	catalogClient interface {
 		GetProductByID(ctx context.Context, productID uuid.UUID) (*catalog.Product, error)
+		GetProductBySKU(ctx context.Context, sku string) (*catalog.Product, error)
 	}
 
 	taxClient interface {
 		CalculateTaxes(ctx context.Context, taxRateID string, amount float64) (float64, error)
 	}
 
+	customerClient interface {
+		GetSegment(ctx context.Context, customerUUID uuid.UUID) (string, error)
+	}
+
 	quoteRepository interface {
 		FindByCustomerAndStatus(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (*types.Quote, error)
+		FindByID(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error)
+		FindByNumber(ctx context.Context, number string) (*types.Quote, error)
+		NextQuoteNumber(ctx context.Context, tenant string, year int) (int, error)
+		ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error
+		FindQuotes(ctx context.Context, query types.QuoteQuery) (*types.QuotePage, error)
 		Save(ctx context.Context, quote *types.Quote) error
+		FindRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error)
+		FindRevision(ctx context.Context, quoteUUID uuid.UUID, number int) (*types.QuoteRevision, error)
+	}
+
+	templateRepository interface {
+		FindTemplates(ctx context.Context) ([]*types.Template, error)
+		FindTemplateByID(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error)
+		SaveTemplate(ctx context.Context, template *types.Template) error
+		DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error
+	}
+
+	shareRepository interface {
+		FindShares(ctx context.Context, quoteUUID uuid.UUID) ([]*types.Share, error)
+		FindShareByID(ctx context.Context, shareUUID uuid.UUID) (*types.Share, error)
+		SaveShare(ctx context.Context, share *types.Share) error
+		RecordShareAccess(ctx context.Context, access types.ShareAccess) error
+		FindShareAccesses(ctx context.Context, shareUUID uuid.UUID) ([]types.ShareAccess, error)
+	}
+
+	shareSigner interface {
+		Sign(claims share.Claims) (string, error)
+		Verify(token string) (*share.Claims, error)
+	}
+
+	Config struct {
+		// PriceOverrideApprovalThreshold is the reduction of the catalog price in percent
+		// up to which a price override is approved without a manager.
+		PriceOverrideApprovalThreshold float64
+		// MaxQuantity is the largest quantity of a quote line, zero means no limit.
+		MaxQuantity int
+		// MaxLines is the largest number of lines of a quote, zero means no limit.
+		MaxLines int
+		// PaymentMethods are the accepted payment methods, every method is accepted when it is empty.
+		PaymentMethods []string
+		// NumberTenant owns the sequence of quote numbers, every tenant numbers its quotes from one each year.
+		NumberTenant string
+		// NumberPrefix starts every quote number, Q when it is empty.
+		NumberPrefix string
+		// ShareTTL is how long a share link is valid when the customer does not ask for a duration.
+		ShareTTL time.Duration
+		// ShareMaxTTL is the longest a share link can be valid, zero means no limit.
+		ShareMaxTTL time.Duration
+		// TermsVersion is the current version of the terms a quote is accepted with, any version is taken
+		// when it is empty.
+		TermsVersion string
+		// AcceptanceSegments are the customer segments whose quotes have to be accepted before they are processed.
+		AcceptanceSegments []string
 	}
 
 	Quote struct {
@@ -35,6 +95,8 @@ type (
 		catalog    catalogClient
 		taxes      taxClient
 		order      orderClient
+		customers  customerClient
+		config     Config
 	}
 )
 
@@ -43,23 +105,31 @@ func NewQuote(
 	catalog catalogClient,
 	taxes taxClient,
 	order orderClient,
+	customers customerClient,
+	config Config,
 ) *Quote {
+	if config.NumberPrefix == "" {
+		config.NumberPrefix = defaultNumberPrefix
+	}
+
 	return &Quote{
 		repository: repository,
 		catalog:    catalog,
 		taxes:      taxes,
 		order:      order,
+		customers:  customers,
+		config:     config,
 	}
 }
 
 // AddProduct adds a new product to the customer's draft quote.
-// If the quote doesn't exist, it creates a new one.
+// If the quote doesn't exist, it creates a new one. A product already in the quote is not added
+// as a second line, the quantity is added to its line, so every line is identified by the product ID.
 func (q *Quote) AddProduct(ctx context.Context, customerUUID uuid.UUID, product *types.ProductAdd) error {
 	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
-		quote.Products = append(quote.Products, types.Product{
-			ProductID: product.ProductID,
-			Quantity:  product.Quantity,
-		})
+		if err := q.addProduct(ctx, quote, product); err != nil {
+			return fmt.Errorf("Domain::Quote::AddProduct : %w", err)
+		}
 
 		if err := q.refresh(ctx, quote); err != nil {
 			return fmt.Errorf("Domain::Quote::AddProduct : %w", err)
@@ -72,18 +142,13 @@ func (q *Quote) AddProduct(ctx context.Context, customerUUID uuid.UUID, product
 // UpdateProduct updates the quantity of a product in the customer's draft quote.
 // Returns an error if the product is not found in the quote.
 func (q *Quote) UpdateProduct(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, product *types.ProductUpdate) error {
+	if err := q.validateProductUpdate(product); err != nil {
+		return fmt.Errorf("Domain::Quote::UpdateProduct : %w", err)
+	}
+
 	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
-		isFound := false
-		n := len(quote.Products)
-		for i := 0; i < n; i++ {
-			if quote.Products[i].ProductID == productUUID {
-				quote.Products[i].Quantity = product.Quantity
-				isFound = true
-				break
-			}
-		}
-		if !isFound {
-			return types.ErrQuoteProductNotFound
+		if err := q.updateProduct(ctx, quote, productUUID, product); err != nil {
+			return fmt.Errorf("Domain::Quote::UpdateProduct : %w", err)
 		}
 
 		if err := q.refresh(ctx, quote); err != nil {
@@ -94,12 +159,114 @@ func (q *Quote) UpdateProduct(ctx context.Context, customerUUID uuid.UUID, produ
 	})
 }
 
+// ApplyDiscount sets a manual discount in percent on a product of the customer's draft quote.
+// Returns a validation error if the discount is not within 0 and 100.
+func (q *Quote) ApplyDiscount(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, discount *types.ProductDiscount) error {
+	if err := validateDiscount(discount); err != nil {
+		return fmt.Errorf("Domain::Quote::ApplyDiscount : %w", err)
+	}
+
+	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		if _, err := findProduct(quote, productUUID); err != nil {
+			return fmt.Errorf("Domain::Quote::ApplyDiscount : %w", err)
+		}
+
+		q.record(ctx, quote, types.ProductDiscountApplied{
+			ProductID: productUUID,
+			Discount:  discount.Discount,
+		})
+
+		if err := q.refresh(ctx, quote); err != nil {
+			return fmt.Errorf("Domain::Quote::ApplyDiscount : %w", err)
+		}
+
+		return nil
+	})
+}
+
+// OverridePrice replaces the catalog unit price of a product in the customer's draft quote.
+// Overrides reducing the catalog price by more than the configured threshold stay pending
+// until a manager approves them.
+func (q *Quote) OverridePrice(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, override *types.ProductPriceOverride) error {
+	if err := validatePriceOverride(override); err != nil {
+		return fmt.Errorf("Domain::Quote::OverridePrice : %w", err)
+	}
+
+	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		if _, err := findProduct(quote, productUUID); err != nil {
+			return fmt.Errorf("Domain::Quote::OverridePrice : %w", err)
+		}
+
+		productInfo, err := q.catalog.GetProductByID(ctx, productUUID)
+		if err != nil {
+			return fmt.Errorf("Domain::Quote::OverridePrice : %w", err)
+		}
+
+		status := types.ApprovalStatusApproved
+		if q.needsApproval(productInfo.Price, override.Price) {
+			status = types.ApprovalStatusPending
+		}
+
+		q.record(ctx, quote, types.ProductPriceOverridden{
+			ProductID: productUUID,
+			Override: types.PriceOverride{
+				Price:        override.Price,
+				CatalogPrice: productInfo.Price,
+				Status:       status,
+				RequestedBy:  actorFromContext(ctx),
+				RequestedAt:  time.Now(),
+			},
+		})
+
+		if err := q.refresh(ctx, quote); err != nil {
+			return fmt.Errorf("Domain::Quote::OverridePrice : %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ApprovePriceOverride approves the pending price override of a product in the customer's draft quote.
+func (q *Quote) ApprovePriceOverride(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID) error {
+	if err := q.decidePriceOverride(ctx, customerUUID, productUUID, types.ApprovalStatusApproved); err != nil {
+		return fmt.Errorf("Domain::Quote::ApprovePriceOverride : %w", err)
+	}
+
+	return nil
+}
+
+// RejectPriceOverride rejects the pending price override of a product in the customer's draft quote,
+// so the catalog price applies again.
+func (q *Quote) RejectPriceOverride(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID) error {
+	if err := q.decidePriceOverride(ctx, customerUUID, productUUID, types.ApprovalStatusRejected); err != nil {
+		return fmt.Errorf("Domain::Quote::RejectPriceOverride : %w", err)
+	}
+
+	return nil
+}
+
 // LoadDraftByCustomer retrieves the customer draft quote or creates a new one if it doesn't exist.
+// The draft is the active draft of the customer, or the draft of the context scope set by types.WithDraftID.
 func (q *Quote) LoadDraftByCustomer(ctx context.Context, customerUUID uuid.UUID) (*types.Quote, error) {
+	if quoteUUID, ok := types.DraftIDFromContext(ctx); ok {
+		quote, err := q.loadDraft(ctx, customerUUID, quoteUUID)
+		if err != nil {
+			return nil, fmt.Errorf("Domain::Quote::LoadDraftByCustomer : %w", err)
+		}
+
+		return quote, nil
+	}
+
 	quote, err := q.repository.FindByCustomerAndStatus(ctx, customerUUID, types.QuoteStatusDraft)
 	if err != nil {
 		if errors.Is(err, types.ErrQuoteNotFound) {
-			return types.NewQuote(uuid.New(), customerUUID), nil
+			quote := types.NewQuote(uuid.New(), customerUUID)
+			q.record(ctx, quote, types.QuoteCreated{
+				CustomerID: quote.CustomerID,
+				CreatedAt:  quote.CreatedAt,
+			})
+
+			return quote, nil
 		}
 
 		return nil, fmt.Errorf("Domain::Quote::LoadDraftByCustomer : %w", err)
@@ -108,17 +275,59 @@ func (q *Quote) LoadDraftByCustomer(ctx context.Context, customerUUID uuid.UUID)
 	return quote, nil
 }
 
-// ProcessByCustomerID processes the customer draft quote and marks it as done.
+// LoadByID returns a quote of the customer in any status, e.g. a processed one.
+// A quote of another customer is reported as not found.
+func (q *Quote) LoadByID(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error) {
+	quote, err := findCustomerQuote(ctx, q.repository, customerUUID, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::LoadByID : %w", err)
+	}
+
+	return quote, nil
+}
+
+// LoadByNumber returns a quote of the customer by its quote number.
+// A quote of another customer is reported as not found.
+func (q *Quote) LoadByNumber(ctx context.Context, customerUUID uuid.UUID, number string) (*types.Quote, error) {
+	quote, err := q.repository.FindByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::LoadByNumber : %w", err)
+	}
+
+	if quote.CustomerID != customerUUID {
+		return nil, fmt.Errorf("Domain::Quote::LoadByNumber : %w", types.ErrQuoteNotFound)
+	}
+
+	return quote, nil
+}
+
+// ProcessByCustomerID processes the customer draft quote and marks it as done. A quote scoped by its ID may also be
+// an accepted quote, the quotes of the customer segments which require an acceptance can only be processed so.
 func (q *Quote) ProcessByCustomerID(ctx context.Context, customerUUID uuid.UUID) error {
-	quote, err := q.LoadDraftByCustomer(ctx, customerUUID)
+	quote, err := q.loadProcessable(ctx, customerUUID)
 	if err != nil {
 		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", err)
 	}
 
-	quote.Status = types.QuoteStatusProcessing
-	quote.UpdatedAt = time.Now()
+	if err := checkPrecondition(ctx, quote); err != nil {
+		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", err)
+	}
 
-	if err := q.repository.Save(ctx, quote); err != nil {
+	if quote.HasPendingApprovals() {
+		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", types.ErrQuoteApprovalPending)
+	}
+
+	if quote.OpenOffer() != nil {
+		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", types.ErrOfferPending)
+	}
+
+	if err := q.checkAcceptance(ctx, quote); err != nil {
+		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", err)
+	}
+
+	q.record(ctx, quote, types.StatusChanged{Status: types.QuoteStatusProcessing})
+
+	if err := q.save(ctx, quote); err != nil {
 		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", err)
 	}
 
@@ -127,10 +336,9 @@ func (q *Quote) ProcessByCustomerID(ctx context.Context, customerUUID uuid.UUID)
 	}
 
 	// it can be changed via events from Order Scheduling Service
-	quote.Status = types.QuoteStatusDone
-	quote.UpdatedAt = time.Now()
+	q.record(ctx, quote, types.StatusChanged{Status: types.QuoteStatusDone})
 
-	if err := q.repository.Save(ctx, quote); err != nil {
+	if err := q.save(ctx, quote); err != nil {
 		return fmt.Errorf("Domain::Quote::ProcessByCustomerID : %w", err)
 	}
 
@@ -141,16 +349,9 @@ func (q *Quote) ProcessByCustomerID(ctx context.Context, customerUUID uuid.UUID)
 // Returns an ErrQuoteProductNotFound error if the product is not found.
 func (q *Quote) RemoveProduct(ctx context.Context, customerUUID uuid.UUID, productID uuid.UUID) error {
 	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
-		filtered := make([]types.Product, 0)
-		for _, product := range quote.Products {
-			if product.ProductID != productID {
-				filtered = append(filtered, product)
-			}
-		}
-		if len(filtered) == len(quote.Products) {
-			return types.ErrQuoteProductNotFound
+		if err := q.removeProduct(ctx, quote, productID); err != nil {
+			return fmt.Errorf("Domain::Quote::RemoveProduct : %w", err)
 		}
-		quote.Products = filtered
 
 		if err := q.refresh(ctx, quote); err != nil {
 			return fmt.Errorf("Domain::Quote::RemoveProduct : %w", err)
@@ -162,8 +363,12 @@ func (q *Quote) RemoveProduct(ctx context.Context, customerUUID uuid.UUID, produ
 
 // SaveAddress saves the customer's address in the draft quote.
 func (q *Quote) SaveAddress(ctx context.Context, customerUUID uuid.UUID, address *types.Address) error {
+	if err := validateAddress(address); err != nil {
+		return fmt.Errorf("Domain::Quote::SaveAddress : %w", err)
+	}
+
 	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
-		quote.Address = address
+		q.record(ctx, quote, types.AddressSaved{Address: address})
 
 		return nil
 	})
@@ -171,44 +376,171 @@ func (q *Quote) SaveAddress(ctx context.Context, customerUUID uuid.UUID, address
 
 // SavePayment saves the customer's payment details in the draft quote.
 func (q *Quote) SavePayment(ctx context.Context, customerUUID uuid.UUID, payment *types.Payment) error {
+	if err := q.validatePayment(payment); err != nil {
+		return fmt.Errorf("Domain::Quote::SavePayment : %w", err)
+	}
+
 	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
-		quote.Payment = payment
+		q.record(ctx, quote, types.PaymentSaved{Payment: payment})
 
 		return nil
 	})
 }
 
-// calculateProduct calculates the tax and total amount for a product in the quote.
-func (q *Quote) calculateProduct(ctx context.Context, product *types.Product) error {
+// addProduct records the product as a new line or merges its quantity into the existing line.
+func (q *Quote) addProduct(ctx context.Context, quote *types.Quote, product *types.ProductAdd) error {
+	if err := q.validateProductAdd(quote, product); err != nil {
+		return fmt.Errorf("Domain::Quote::addProduct : %w", err)
+	}
+
+	if existing, err := findProduct(quote, product.ProductID); err == nil {
+		q.record(ctx, quote, types.ProductQuantityChanged{
+			ProductID: product.ProductID,
+			Quantity:  existing.Quantity + product.Quantity,
+		})
+	} else {
+		q.record(ctx, quote, types.ProductAdded{
+			ProductID: product.ProductID,
+			Quantity:  product.Quantity,
+		})
+	}
+
+	return nil
+}
+
+func (q *Quote) updateProduct(ctx context.Context, quote *types.Quote, productUUID uuid.UUID, product *types.ProductUpdate) error {
+	if _, err := findProduct(quote, productUUID); err != nil {
+		return fmt.Errorf("Domain::Quote::updateProduct : %w", err)
+	}
+
+	q.record(ctx, quote, types.ProductQuantityChanged{
+		ProductID: productUUID,
+		Quantity:  product.Quantity,
+	})
+
+	return nil
+}
+
+func (q *Quote) removeProduct(ctx context.Context, quote *types.Quote, productUUID uuid.UUID) error {
+	if _, err := findProduct(quote, productUUID); err != nil {
+		return fmt.Errorf("Domain::Quote::removeProduct : %w", err)
+	}
+
+	q.record(ctx, quote, types.ProductRemoved{ProductID: productUUID})
+
+	return nil
+}
+
+// calculateProduct calculates the tax and total amount for a product in the quote. The price and discount
+// agreed in a negotiation replace the catalog price, the price override and the manual discount of the line.
+func (q *Quote) calculateProduct(ctx context.Context, product *types.Product, agreed *types.OfferLine) (types.LinePrice, error) {
 	productInfo, err := q.catalog.GetProductByID(ctx, product.ProductID)
 	if err != nil {
-		return fmt.Errorf("Domain::Quote::calculateProduct : %w", err)
+		return types.LinePrice{}, fmt.Errorf("Domain::Quote::calculateProduct : %w", err)
+	}
+
+	price, discount := productInfo.Price, product.Discount
+	if product.Override != nil && product.Override.Status != types.ApprovalStatusRejected {
+		price = product.Override.Price
+	}
+	if agreed != nil {
+		price, discount = agreed.Price, agreed.Discount
 	}
 
-	product.Amount = float64(product.Quantity) * productInfo.Price
-	product.TaxAmount, err = q.taxes.CalculateTaxes(ctx, productInfo.TaxRateID, product.Amount)
+	line := types.LinePrice{ProductID: product.ProductID}
+	line.Amount = float64(product.Quantity) * price * (1 - discount/100)
+	line.TaxAmount, err = q.taxes.CalculateTaxes(ctx, productInfo.TaxRateID, line.Amount)
 	if err != nil {
-		return fmt.Errorf("Domain::Quote::calculateProduct : %w", err)
+		return types.LinePrice{}, fmt.Errorf("Domain::Quote::calculateProduct : %w", err)
 	}
 
-	product.TotalAmount = product.Amount + product.TaxAmount
-	return nil
+	line.TotalAmount = line.Amount + line.TaxAmount
+	return line, nil
+}
+
+// needsApproval reports whether the price reduces the catalog price above the approval threshold.
+func (q *Quote) needsApproval(catalogPrice float64, price float64) bool {
+	if catalogPrice <= 0 {
+		return false
+	}
+
+	reduction := (catalogPrice - price) / catalogPrice * 100
+	return reduction > q.config.PriceOverrideApprovalThreshold
+}
+
+// decidePriceOverride records the manager decision on a pending price override, or on the pending price of the
+// line agreed in the negotiation when the line has no pending override.
+func (q *Quote) decidePriceOverride(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, status types.ApprovalStatus) error {
+	return q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		product, err := findProduct(quote, productUUID)
+		if err != nil {
+			return fmt.Errorf("Domain::Quote::decidePriceOverride : %w", err)
+		}
+
+		agreed := quote.AgreedOffer()
+		var agreedLine *types.OfferLine
+		if agreed != nil {
+			agreedLine = agreed.Line(productUUID)
+		}
+
+		switch {
+		case product.Override != nil && product.Override.Status == types.ApprovalStatusPending:
+			q.record(ctx, quote, types.PriceOverrideDecided{
+				ProductID: productUUID,
+				Status:    status,
+				DecidedBy: actorFromContext(ctx),
+				DecidedAt: time.Now(),
+			})
+		case agreedLine != nil && agreedLine.Approval == types.ApprovalStatusPending:
+			q.record(ctx, quote, types.OfferLineDecided{
+				Number:    agreed.Number,
+				ProductID: productUUID,
+				Status:    status,
+				DecidedBy: actorFromContext(ctx),
+				DecidedAt: time.Now(),
+			})
+		default:
+			return fmt.Errorf("Domain::Quote::decidePriceOverride : %w", types.ErrQuoteNoPendingPrice)
+		}
+
+		if err := q.refresh(ctx, quote); err != nil {
+			return fmt.Errorf("Domain::Quote::decidePriceOverride : %w", err)
+		}
+
+		return nil
+	})
 }
 
-// refresh recalculates the totals for the quote based on its products.
+// refresh recalculates the totals for the quote based on its products and the prices of the agreed offer.
 func (q *Quote) refresh(ctx context.Context, quote *types.Quote) error {
-	quote.Amount, quote.TaxAmount, quote.TotalAmount = 0, 0, 0
+	repriced := types.QuoteRepriced{
+		Lines: make([]types.LinePrice, 0, len(quote.Products)),
+	}
 
+	agreed := quote.AgreedOffer()
 	for i := range quote.Products {
-		if err := q.calculateProduct(ctx, &quote.Products[i]); err != nil {
+		var agreedLine *types.OfferLine
+		if agreed != nil {
+			agreedLine = agreed.Line(quote.Products[i].ProductID)
+		}
+		// a rejected agreed price does not apply, the line keeps its own price
+		if agreedLine != nil && agreedLine.Approval == types.ApprovalStatusRejected {
+			agreedLine = nil
+		}
+
+		line, err := q.calculateProduct(ctx, &quote.Products[i], agreedLine)
+		if err != nil {
 			return fmt.Errorf("Domain::Quote::refresh : %w", err)
 		}
 
-		quote.Amount += quote.Products[i].Amount
-		quote.TaxAmount += quote.Products[i].TaxAmount
-		quote.TotalAmount += quote.Products[i].TotalAmount
+		repriced.Lines = append(repriced.Lines, line)
+		repriced.Amount += line.Amount
+		repriced.TaxAmount += line.TaxAmount
+		repriced.TotalAmount += line.TotalAmount
 	}
 
+	q.record(ctx, quote, repriced)
+
 	return nil
 }
 
@@ -220,18 +552,116 @@ func (q *Quote) withDraft(ctx context.Context, customerUUID uuid.UUID, action fu
 			return fmt.Errorf("Domain::Quote::withDraft : %w", err)
 		}
 
+		if err := checkPrecondition(ctx, quote); err != nil {
+			return fmt.Errorf("Domain::Quote::withDraft : %w", err)
+		}
+
 		if err := action(quote); err != nil {
 			return fmt.Errorf("Domain::Quote::withDraft : %w", err)
 		}
 
-		quote.UpdatedAt = time.Now()
-		if err := q.repository.Save(ctx, quote); err != nil {
+		if err := q.save(ctx, quote); err != nil {
 			return fmt.Errorf("Domain::Quote::withDraft : %w", err)
 		}
 		return nil
 	})
 }
 
+// record applies a change to the quote as an event made now by the actor of the context.
+func (q *Quote) record(ctx context.Context, quote *types.Quote, data types.EventData) {
+	quote.Record(types.Event{
+		OccurredAt: time.Now(),
+		Actor:      actorFromContext(ctx),
+		Data:       data,
+	})
+}
+
+// save commits the changes recorded since the quote was loaded as its next revision.
+func (q *Quote) save(ctx context.Context, quote *types.Quote) error {
+	if quote.Number == "" {
+		if err := q.assignNumber(ctx, quote); err != nil {
+			return fmt.Errorf("Domain::Quote::save : %w", err)
+		}
+	}
+
+	touch(ctx, quote)
+	if err := q.repository.Save(ctx, quote); err != nil {
+		return fmt.Errorf("Domain::Quote::save : %w", err)
+	}
+
+	quote.Changes = nil
+	return nil
+}
+
+// assignNumber gives the quote the next number of the tenant in the current year. A number allocated
+// for a save that fails afterwards is not reused, so the numbers may have gaps but are never taken twice.
+func (q *Quote) assignNumber(ctx context.Context, quote *types.Quote) error {
+	year := time.Now().Year()
+	sequence, err := q.repository.NextQuoteNumber(ctx, q.config.NumberTenant, year)
+	if err != nil {
+		return fmt.Errorf("Domain::Quote::assignNumber : %w", err)
+	}
+
+	q.record(ctx, quote, types.QuoteNumbered{Number: types.FormatQuoteNumber(q.config.NumberPrefix, year, sequence)})
+	return nil
+}
+
+// findCustomerQuote returns the quote by its ID, a quote of another customer is reported as not found.
+func findCustomerQuote(ctx context.Context, repository quoteRepository, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error) {
+	quote, err := repository.FindByID(ctx, quoteUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if quote.CustomerID != customerUUID {
+		return nil, types.ErrQuoteNotFound
+	}
+
+	return quote, nil
+}
+
+// touch marks the quote as changed now by the actor of the context and starts its next revision.
+func touch(ctx context.Context, quote *types.Quote) {
+	quote.Revision++
+	quote.UpdatedAt = time.Now()
+	quote.UpdatedBy = actorFromContext(ctx)
+
+	for i := range quote.Changes {
+		quote.Changes[i].Revision = quote.Revision
+		quote.Changes[i].OccurredAt = quote.UpdatedAt
+	}
+}
+
+// checkPrecondition rejects the change when the client expects another state of the quote than the stored one.
+func checkPrecondition(ctx context.Context, quote *types.Quote) error {
+	etags, ok := types.ExpectedETagsFromContext(ctx)
+	if ok && !quote.MatchesETag(etags) {
+		return types.ErrQuoteModified
+	}
+
+	return nil
+}
+
+func actorFromContext(ctx context.Context) *types.Actor {
+	actor, ok := types.ActorFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return &actor
+}
+
+// findProduct returns the product line of the quote, so it can be changed in place.
+func findProduct(quote *types.Quote, productUUID uuid.UUID) (*types.Product, error) {
+	for i := range quote.Products {
+		if quote.Products[i].ProductID == productUUID {
+			return &quote.Products[i], nil
+		}
+	}
+
+	return nil, types.ErrQuoteProductNotFound
+}
+
 func (q *Quote) withLock(ctx context.Context, customerUUID uuid.UUID, action func() error) error {
 	// Implementation of distributed lock should go here using AWS Memcached-Redis-similar product
 	return action()
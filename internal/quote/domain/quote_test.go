@@ -1,11 +1,15 @@
 package domain_test
 
 import (
+	"app/internal/catalog"
 	"app/internal/quote/domain"
 	mockDomain "app/internal/quote/domain/mock"
+	"app/internal/quote/share"
 	"app/internal/quote/types"
 	"context"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -13,22 +17,52 @@ import (
 )
 
 type testUnitQuote struct {
-	service       *domain.Quote
-	repository    *mockDomain.MockquoteRepository
-	taxClient     *mockDomain.MocktaxClient
-	catalogClient *mockDomain.MockcatalogClient
+	service         *domain.Quote
+	templateService *domain.Template
+	shareService    *domain.Share
+	repository      *mockDomain.MockquoteRepository
+	templates       *mockDomain.MocktemplateRepository
+	shares          *mockDomain.MockshareRepository
+	signer          *share.Signer
+	taxClient       *mockDomain.MocktaxClient
+	catalogClient   *mockDomain.MockcatalogClient
+	orderClient     *mockDomain.MockorderClient
+	customers       *mockDomain.MockcustomerClient
 }
 
 func newTestUnitQuote(ctrl *gomock.Controller) *testUnitQuote {
 	repository := mockDomain.NewMockquoteRepository(ctrl)
+	templates := mockDomain.NewMocktemplateRepository(ctrl)
+	shares := mockDomain.NewMockshareRepository(ctrl)
+	signer := share.NewSigner(share.Config{Key: "test-secret"})
 	taxClient := mockDomain.NewMocktaxClient(ctrl)
 	catalogClient := mockDomain.NewMockcatalogClient(ctrl)
+	orderClient := mockDomain.NewMockorderClient(ctrl)
+	customers := mockDomain.NewMockcustomerClient(ctrl)
+
+	config := domain.Config{
+		PriceOverrideApprovalThreshold: 10,
+		MaxQuantity:                    100,
+		MaxLines:                       2,
+		PaymentMethods:                 []string{"card", "invoice"},
+		NumberTenant:                   "meisterwerk",
+		ShareMaxTTL:                    30 * 24 * time.Hour,
+		TermsVersion:                   "2026-01",
+		AcceptanceSegments:             []string{"enterprise"},
+	}
 
 	return &testUnitQuote{
-		repository:    repository,
-		taxClient:     taxClient,
-		catalogClient: catalogClient,
-		service:       domain.NewQuote(repository, catalogClient, taxClient),
+		repository:      repository,
+		templates:       templates,
+		shares:          shares,
+		signer:          signer,
+		taxClient:       taxClient,
+		catalogClient:   catalogClient,
+		orderClient:     orderClient,
+		customers:       customers,
+		service:         domain.NewQuote(repository, catalogClient, taxClient, orderClient, customers, config),
+		templateService: domain.NewTemplate(templates, catalogClient, config),
+		shareService:    domain.NewShare(repository, shares, signer, config),
 	}
 }
 
@@ -87,6 +121,90 @@ func TestQuoteLoadDraftByCustomerExists(t *testing.T) {
 	assertQuoteEqual(t, expected, actual)
 }
 
+func TestQuoteLoadByIDOtherCustomer(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	ctx := context.Background()
+	quote := types.NewQuote(uuid.New(), uuid.New())
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	// act
+	actual, err := tc.service.LoadByID(ctx, uuid.New(), quote.UUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteNotFound)
+	assert.Nil(t, actual)
+}
+
+func TestQuoteLoadByNumberOtherCustomer(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	ctx := context.Background()
+	quote := types.NewQuote(uuid.New(), uuid.New())
+	quote.Number = "Q-2026-000001"
+
+	tc.repository.EXPECT().
+		FindByNumber(gomock.Any(), gomock.Eq(quote.Number)).
+		Return(quote, nil)
+
+	// act
+	actual, err := tc.service.LoadByNumber(ctx, uuid.New(), quote.Number)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteNotFound)
+	assert.Nil(t, actual)
+}
+
+func TestQuoteSaveAssignsNumber(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	year := time.Now().Year()
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(nil, types.ErrQuoteNotFound)
+	tc.repository.EXPECT().
+		NextQuoteNumber(gomock.Any(), gomock.Eq("meisterwerk"), gomock.Eq(year)).
+		Return(123, nil)
+
+	var saved *types.Quote
+	var changes []types.Event
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			saved = q
+			changes = q.Changes
+			return nil
+		})
+
+	// act
+	err := tc.service.SaveAddress(context.Background(), customerUUID, &types.Address{Address: "Main St. 1", City: "Berlin", Country: "DE"})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("Q-%d-000123", year), saved.Number)
+	assert.Contains(t, changes, types.Event{
+		QuoteID:    saved.UUID,
+		Version:    3,
+		Revision:   1,
+		OccurredAt: saved.UpdatedAt,
+		Data:       types.QuoteNumbered{Number: saved.Number},
+	})
+}
+
 func TestQuoteAddProduct(t *testing.T) {
 }
 
@@ -126,8 +244,443 @@ func TestQuoteSaveAddress(t *testing.T) {
 func TestQuoteSaveAddressFailed(t *testing.T) {
 }
 
+func TestQuoteApplyDiscount(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+	actor := types.Actor{Type: types.ActorTypeAgent, ID: uuid.NewString()}
+	ctx := types.WithActor(context.Background(), actor)
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Products = []types.Product{{ProductID: productUUID, Quantity: 2}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 50, TaxRateID: "standard"}, nil)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(90.0)).
+		Return(9.0, nil)
+
+	var saved *types.Quote
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			saved = q
+			return nil
+		})
+
+	// act
+	err := tc.service.ApplyDiscount(ctx, customerUUID, productUUID, &types.ProductDiscount{Discount: 10})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, 10.0, saved.Products[0].Discount)
+	assert.Equal(t, 90.0, saved.Amount)
+	assert.Equal(t, 99.0, saved.TotalAmount)
+	assert.Equal(t, &actor, saved.UpdatedBy)
+}
+
+func TestQuoteApplyDiscountInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+
+	// act
+	err := tc.service.ApplyDiscount(context.Background(), uuid.New(), uuid.New(), &types.ProductDiscount{Discount: 120})
+
+	// assert
+	var validation *types.ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, []types.FieldError{{Field: "discount_percent", Message: "discount must be between 0 and 100"}}, validation.Fields)
+}
+
+func TestQuoteAddProductExistingLine(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Products = []types.Product{{ProductID: productUUID, Quantity: 2}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 10, TaxRateID: "standard"}, nil)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(50.0)).
+		Return(5.0, nil)
+
+	var changes []types.Event
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, q *types.Quote) error {
+			changes = q.Changes
+			return nil
+		})
+
+	// act
+	err := tc.service.AddProduct(context.Background(), customerUUID, &types.ProductAdd{ProductID: productUUID, Quantity: 3})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, quote.Products, 1)
+	assert.Equal(t, 5, quote.Products[0].Quantity)
+	assert.Equal(t, 55.0, quote.TotalAmount)
+	assert.Equal(t, types.ProductQuantityChanged{ProductID: productUUID, Quantity: 5}, changes[0].Data)
+}
+
+func TestQuoteAddProductInvalid(t *testing.T) {
+	existingUUID := uuid.New()
+
+	tests := []struct {
+		name     string
+		products []types.Product
+		product  types.ProductAdd
+		fields   []types.FieldError
+	}{
+		{
+			name:    "missing product and zero quantity",
+			product: types.ProductAdd{Quantity: 0},
+			fields: []types.FieldError{
+				{Field: "product_id", Message: "product id is required"},
+				{Field: "qty", Message: "quantity must be at least 1"},
+			},
+		},
+		{
+			name:    "quantity above limit",
+			product: types.ProductAdd{ProductID: uuid.New(), Quantity: 101},
+			fields: []types.FieldError{
+				{Field: "qty", Message: "quantity must be at most 100"},
+			},
+		},
+		{
+			name:     "merged quantity above limit",
+			products: []types.Product{{ProductID: existingUUID, Quantity: 60}},
+			product:  types.ProductAdd{ProductID: existingUUID, Quantity: 50},
+			fields: []types.FieldError{
+				{Field: "qty", Message: "quantity of the line would exceed 100"},
+			},
+		},
+		{
+			name:     "too many lines",
+			products: []types.Product{{ProductID: uuid.New(), Quantity: 1}, {ProductID: uuid.New(), Quantity: 1}},
+			product:  types.ProductAdd{ProductID: uuid.New(), Quantity: 1},
+			fields: []types.FieldError{
+				{Field: "product_id", Message: "quote can not have more than 2 lines"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+			customerUUID := uuid.New()
+
+			quote := types.NewQuote(uuid.New(), customerUUID)
+			quote.Products = tt.products
+
+			tc.repository.EXPECT().
+				FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+				Return(quote, nil)
+
+			// act
+			err := tc.service.AddProduct(context.Background(), customerUUID, &tt.product)
+
+			// assert
+			var validationError *types.ValidationError
+			assert.ErrorAs(t, err, &validationError)
+			assert.ErrorIs(t, err, types.ErrQuoteValidation)
+			assert.Equal(t, tt.fields, validationError.Fields)
+		})
+	}
+}
+
+func TestQuoteSaveAddressInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+
+	// act
+	err := tc.service.SaveAddress(context.Background(), uuid.New(), &types.Address{City: "Berlin", Country: "Germany"})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, []types.FieldError{
+		{Field: "address", Message: "address is required"},
+		{Field: "country", Message: "country must be an ISO 3166-1 alpha-2 code"},
+	}, validationError.Fields)
+}
+
+func TestQuoteSavePaymentInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+
+	// act
+	err := tc.service.SavePayment(context.Background(), uuid.New(), &types.Payment{PaymentMethod: "cash"})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Equal(t, []types.FieldError{
+		{Field: "payment_method", Message: "payment method must be one of card, invoice"},
+	}, validationError.Fields)
+}
+
+func TestQuoteSaveAddressModified(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Revision = 2
+	quote.Version = 5
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	ctx := types.WithExpectedETags(context.Background(), []string{fmt.Sprintf(`"%s.4"`, quote.UUID)})
+
+	// act
+	err := tc.service.SaveAddress(ctx, customerUUID, &types.Address{Address: "Main St. 1", City: "Berlin", Country: "DE"})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteModified)
+}
+
+func TestQuoteSaveAddressAnyETagUnsaved(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(nil, types.ErrQuoteNotFound)
+
+	ctx := types.WithExpectedETags(context.Background(), []string{"*"})
+
+	// act
+	err := tc.service.SaveAddress(ctx, customerUUID, &types.Address{Address: "Main St. 1", City: "Berlin", Country: "DE"})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteModified)
+}
+
+func TestQuoteOverridePrice(t *testing.T) {
+	tests := []struct {
+		name           string
+		price          float64
+		expectedStatus types.ApprovalStatus
+	}{
+		{name: "within threshold", price: 95, expectedStatus: types.ApprovalStatusApproved},
+		{name: "above threshold", price: 80, expectedStatus: types.ApprovalStatusPending},
+		{name: "above catalog price", price: 120, expectedStatus: types.ApprovalStatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+			ctx := context.Background()
+			customerUUID := uuid.New()
+			productUUID := uuid.New()
+
+			quote := types.NewQuote(uuid.New(), customerUUID)
+			quote.Number = "Q-2026-000001"
+			quote.Products = []types.Product{{ProductID: productUUID, Quantity: 1}}
+
+			tc.repository.EXPECT().
+				FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+				Return(quote, nil)
+			tc.catalogClient.EXPECT().
+				GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+				Return(&catalog.Product{ProductID: productUUID, Price: 100, TaxRateID: "standard"}, nil).
+				Times(2)
+			tc.taxClient.EXPECT().
+				CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(tt.price)).
+				Return(0.0, nil)
+			tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+			// act
+			err := tc.service.OverridePrice(ctx, customerUUID, productUUID, &types.ProductPriceOverride{Price: tt.price})
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, quote.Products[0].Override.Status)
+			assert.Equal(t, tt.price, quote.Amount)
+		})
+	}
+}
+
+func TestQuoteRejectPriceOverride(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	ctx := context.Background()
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Products = []types.Product{{
+		ProductID: productUUID,
+		Quantity:  1,
+		Override:  &types.PriceOverride{Price: 50, CatalogPrice: 100, Status: types.ApprovalStatusPending},
+	}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 100, TaxRateID: "standard"}, nil)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Eq(100.0)).
+		Return(0.0, nil)
+	tc.repository.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+
+	// act
+	err := tc.service.RejectPriceOverride(ctx, customerUUID, productUUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, types.ApprovalStatusRejected, quote.Products[0].Override.Status)
+	assert.Equal(t, 100.0, quote.Amount)
+}
+
+func TestQuoteProcessByCustomerIDApprovalPending(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Products = []types.Product{{
+		ProductID: uuid.New(),
+		Quantity:  1,
+		Override:  &types.PriceOverride{Price: 50, CatalogPrice: 100, Status: types.ApprovalStatusPending},
+	}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	err := tc.service.ProcessByCustomerID(context.Background(), customerUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteApprovalPending)
+}
+
 func TestQuoteSavePayment(t *testing.T) {
 }
 
 func TestQuoteSavePaymentFailed(t *testing.T) {
 }
+
+func TestQuoteDiffRevisions(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	ctx := context.Background()
+	customerUUID := uuid.New()
+	keptUUID, changedUUID, removedUUID, addedUUID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	from := types.QuoteRevision{Number: 1, Quote: types.Quote{
+		Products: []types.Product{
+			{ProductID: keptUUID, Quantity: 1, Amount: 10, TotalAmount: 12},
+			{ProductID: changedUUID, Quantity: 1, Amount: 5, TotalAmount: 6},
+			{ProductID: removedUUID, Quantity: 3, Amount: 30, TotalAmount: 36},
+		},
+		Amount:      45,
+		TotalAmount: 54,
+	}}
+	to := types.QuoteRevision{Number: 3, Quote: types.Quote{
+		Products: []types.Product{
+			{ProductID: keptUUID, Quantity: 1, Amount: 10, TotalAmount: 12},
+			{ProductID: changedUUID, Quantity: 2, Amount: 10, TotalAmount: 12},
+			{ProductID: addedUUID, Quantity: 1, Amount: 1, TotalAmount: 1.2},
+		},
+		Amount:      21,
+		TotalAmount: 25.2,
+	}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.repository.EXPECT().FindRevision(gomock.Any(), gomock.Eq(quote.UUID), gomock.Eq(1)).Return(&from, nil)
+	tc.repository.EXPECT().FindRevision(gomock.Any(), gomock.Eq(quote.UUID), gomock.Eq(3)).Return(&to, nil)
+
+	// act
+	diff, err := tc.service.DiffRevisions(ctx, customerUUID, 1, 3)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.LineDiff{
+		{
+			ProductID:   changedUUID,
+			Change:      types.LineChangeChanged,
+			Quantity:    types.QuantityDiff{From: 1, To: 2},
+			Amount:      types.AmountDiff{From: 5, To: 10},
+			TotalAmount: types.AmountDiff{From: 6, To: 12},
+		},
+		{
+			ProductID:   addedUUID,
+			Change:      types.LineChangeAdded,
+			Quantity:    types.QuantityDiff{From: 0, To: 1},
+			Amount:      types.AmountDiff{From: 0, To: 1},
+			TotalAmount: types.AmountDiff{From: 0, To: 1.2},
+		},
+		{
+			ProductID:   removedUUID,
+			Change:      types.LineChangeRemoved,
+			Quantity:    types.QuantityDiff{From: 3, To: 0},
+			Amount:      types.AmountDiff{From: 30, To: 0},
+			TotalAmount: types.AmountDiff{From: 36, To: 0},
+		},
+	}, diff.Lines)
+	assert.Equal(t, types.AmountDiff{From: 54, To: 25.2}, diff.TotalAmount)
+}
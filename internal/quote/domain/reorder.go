@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+// Reorder copies the lines of any quote of the customer, e.g. a processed one, into the customer's draft quote
+// and reprices the draft against the current catalog prices. Discounts and price overrides of the source quote
+// are not copied. Lines of discontinued products and lines the draft does not accept are skipped and reported
+// in the result instead of failing the reorder.
+func (q *Quote) Reorder(ctx context.Context, customerUUID uuid.UUID, reorder types.Reorder) (*types.ReorderResult, error) {
+	source, err := q.LoadByID(ctx, customerUUID, reorder.SourceID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::Reorder : %w", err)
+	}
+
+	// the address and payment are saved as if the customer entered them again, an old quote may predate the rules
+	if reorder.IncludeAddress && source.Address != nil {
+		if err := validateAddress(source.Address); err != nil {
+			return nil, fmt.Errorf("Domain::Quote::Reorder : %w", err)
+		}
+	}
+	if reorder.IncludePayment && source.Payment != nil {
+		if err := q.validatePayment(source.Payment); err != nil {
+			return nil, fmt.Errorf("Domain::Quote::Reorder : %w", err)
+		}
+	}
+
+	result := &types.ReorderResult{
+		Copied:  make([]types.ProductOperationResult, 0, len(source.Products)),
+		Skipped: make([]types.ReorderSkip, 0),
+	}
+
+	err = q.withDraft(ctx, customerUUID, func(quote *types.Quote) error {
+		if quote.UUID == source.UUID {
+			validation := &types.ValidationError{}
+			validation.Add("source_id", "quote can not be reordered into itself")
+
+			return validation
+		}
+
+		for i, product := range source.Products {
+			skip, err := q.copyProduct(ctx, quote, product)
+			if err != nil {
+				return err
+			}
+			if skip != nil {
+				skip.Index = i
+				result.Skipped = append(result.Skipped, *skip)
+				continue
+			}
+
+			// the copied quantity, a line already on the draft holds the sum of both
+			result.Copied = append(result.Copied, types.ProductOperationResult{
+				Index:     i,
+				Type:      types.ProductOperationAdd,
+				ProductID: product.ProductID,
+				Quantity:  product.Quantity,
+			})
+		}
+
+		if reorder.IncludeAddress && source.Address != nil {
+			q.record(ctx, quote, types.AddressSaved{Address: source.Address})
+		}
+		if reorder.IncludePayment && source.Payment != nil {
+			q.record(ctx, quote, types.PaymentSaved{Payment: source.Payment})
+		}
+
+		return q.refresh(ctx, quote)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::Reorder : %w", err)
+	}
+
+	return result, nil
+}
+
+// copyProduct adds the quantity of the source line to the draft. A line which can not be copied is returned
+// as skipped, errors are only returned when the catalog fails.
+func (q *Quote) copyProduct(ctx context.Context, quote *types.Quote, product types.Product) (*types.ReorderSkip, error) {
+	skip := &types.ReorderSkip{ProductID: product.ProductID, Quantity: product.Quantity}
+
+	if _, err := q.catalog.GetProductByID(ctx, product.ProductID); err != nil {
+		if errors.Is(err, catalog.ErrProductNotFound) {
+			skip.Reason = types.ReorderSkipDiscontinued
+			skip.Message = "product is not in the catalog anymore"
+
+			return skip, nil
+		}
+
+		return nil, err
+	}
+
+	err := q.addProduct(ctx, quote, &types.ProductAdd{ProductID: product.ProductID, Quantity: product.Quantity})
+	if err != nil {
+		var validation *types.ValidationError
+		if !errors.As(err, &validation) {
+			return nil, err
+		}
+
+		messages := make([]string, 0, len(validation.Fields))
+		for _, field := range validation.Fields {
+			messages = append(messages, field.Message)
+		}
+		skip.Reason = types.ReorderSkipInvalid
+		skip.Message = strings.Join(messages, "; ")
+
+		return skip, nil
+	}
+
+	return nil, nil
+}
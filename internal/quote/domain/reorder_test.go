@@ -0,0 +1,137 @@
+package domain_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+func TestQuoteReorder(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID, discontinuedUUID, bulkUUID := uuid.New(), uuid.New(), uuid.New()
+
+	source := types.NewQuote(uuid.New(), customerUUID)
+	source.Status = types.QuoteStatusDone
+	source.Address = &types.Address{Address: "Main St. 1", City: "Berlin", Country: "DE"}
+	source.Payment = &types.Payment{PaymentMethod: "invoice"}
+	source.Products = []types.Product{
+		{ProductID: productUUID, Quantity: 2, Discount: 10},
+		{ProductID: discontinuedUUID, Quantity: 1},
+		{ProductID: bulkUUID, Quantity: 150},
+	}
+
+	draft := types.NewQuote(uuid.New(), customerUUID)
+	draft.Number = "Q-2026-000002"
+	draft.Products = []types.Product{{ProductID: productUUID, Quantity: 1}}
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(source.UUID)).
+		Return(source, nil)
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(draft, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, productID uuid.UUID) (*catalog.Product, error) {
+			if productID == discontinuedUUID {
+				return nil, catalog.ErrProductNotFound
+			}
+			return &catalog.Product{ProductID: productID, Price: 10, TaxRateID: "standard"}, nil
+		}).
+		AnyTimes()
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Any()).
+		Return(0.0, nil)
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	// act
+	result, err := tc.service.Reorder(context.Background(), customerUUID, types.Reorder{
+		SourceID:       source.UUID,
+		IncludeAddress: true,
+		IncludePayment: true,
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ProductOperationResult{
+		{Index: 0, Type: types.ProductOperationAdd, ProductID: productUUID, Quantity: 2},
+	}, result.Copied)
+	assert.Equal(t, []types.ReorderSkip{
+		{Index: 1, ProductID: discontinuedUUID, Quantity: 1, Reason: types.ReorderSkipDiscontinued, Message: "product is not in the catalog anymore"},
+		{Index: 2, ProductID: bulkUUID, Quantity: 150, Reason: types.ReorderSkipInvalid, Message: "quantity must be at most 100"},
+	}, result.Skipped)
+	// the copied quantity of 2 is merged into the line of 1 already on the draft
+	assert.Equal(t, []types.Product{{ProductID: productUUID, Quantity: 3, Amount: 30, TotalAmount: 30}}, draft.Products)
+	assert.Equal(t, source.Address, draft.Address)
+	assert.Equal(t, source.Payment, draft.Payment)
+	assert.Equal(t, 30.0, draft.TotalAmount)
+}
+
+func TestQuoteReorderItself(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	draft := types.NewQuote(uuid.New(), customerUUID)
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(draft.UUID)).
+		Return(draft, nil)
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(draft, nil)
+
+	// act
+	result, err := tc.service.Reorder(context.Background(), customerUUID, types.Reorder{SourceID: draft.UUID})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteValidation)
+	assert.Nil(t, result)
+}
+
+func TestQuoteReorderInvalidAddress(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+
+	source := types.NewQuote(uuid.New(), customerUUID)
+	source.Status = types.QuoteStatusDone
+	source.Address = &types.Address{Address: "Main St. 1", City: "Berlin", Country: "XX"}
+	source.Products = []types.Product{{ProductID: uuid.New(), Quantity: 2}}
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(source.UUID)).
+		Return(source, nil)
+
+	// act
+	result, err := tc.service.Reorder(context.Background(), customerUUID, types.Reorder{
+		SourceID:       source.UUID,
+		IncludeAddress: true,
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, result)
+	assert.Equal(t, []types.FieldError{
+		{Field: "country", Message: "country must be an ISO 3166-1 alpha-2 code"},
+	}, validationError.Fields)
+}
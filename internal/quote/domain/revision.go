@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+// ListRevisions returns all stored revisions of the customer's draft quote, oldest first.
+func (q *Quote) ListRevisions(ctx context.Context, customerUUID uuid.UUID) ([]types.QuoteRevision, error) {
+	quote, err := q.LoadDraftByCustomer(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ListRevisions : %w", err)
+	}
+
+	revisions, err := q.repository.FindRevisions(ctx, quote.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ListRevisions : %w", err)
+	}
+
+	return revisions, nil
+}
+
+// LoadRevision returns a single revision of the customer's draft quote.
+func (q *Quote) LoadRevision(ctx context.Context, customerUUID uuid.UUID, number int) (*types.QuoteRevision, error) {
+	quote, err := q.LoadDraftByCustomer(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::LoadRevision : %w", err)
+	}
+
+	revision, err := q.repository.FindRevision(ctx, quote.UUID, number)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::LoadRevision : %w", err)
+	}
+
+	return revision, nil
+}
+
+// DiffRevisions compares two revisions of the customer's draft quote line by line.
+func (q *Quote) DiffRevisions(ctx context.Context, customerUUID uuid.UUID, from int, to int) (*types.QuoteDiff, error) {
+	quote, err := q.LoadDraftByCustomer(ctx, customerUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::DiffRevisions : %w", err)
+	}
+
+	fromRevision, err := q.repository.FindRevision(ctx, quote.UUID, from)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::DiffRevisions : %w", err)
+	}
+
+	toRevision, err := q.repository.FindRevision(ctx, quote.UUID, to)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::DiffRevisions : %w", err)
+	}
+
+	diff := diffQuotes(&fromRevision.Quote, &toRevision.Quote)
+	diff.QuoteID = quote.UUID
+	diff.From, diff.To = from, to
+
+	return diff, nil
+}
+
+// diffQuotes lists added, removed and changed product lines and the change of the totals.
+func diffQuotes(from *types.Quote, to *types.Quote) *types.QuoteDiff {
+	diff := &types.QuoteDiff{
+		Lines:       make([]types.LineDiff, 0),
+		Amount:      types.AmountDiff{From: from.Amount, To: to.Amount},
+		TaxAmount:   types.AmountDiff{From: from.TaxAmount, To: to.TaxAmount},
+		TotalAmount: types.AmountDiff{From: from.TotalAmount, To: to.TotalAmount},
+	}
+
+	fromProducts := make(map[uuid.UUID]types.Product, len(from.Products))
+	for _, product := range from.Products {
+		fromProducts[product.ProductID] = product
+	}
+
+	for _, product := range to.Products {
+		previous, ok := fromProducts[product.ProductID]
+		delete(fromProducts, product.ProductID)
+
+		if !ok {
+			diff.Lines = append(diff.Lines, lineDiff(types.LineChangeAdded, types.Product{ProductID: product.ProductID}, product))
+			continue
+		}
+
+		if previous.Quantity != product.Quantity || previous.Amount != product.Amount || previous.TotalAmount != product.TotalAmount {
+			diff.Lines = append(diff.Lines, lineDiff(types.LineChangeChanged, previous, product))
+		}
+	}
+
+	// keep the order of the older revision for removed lines
+	for _, product := range from.Products {
+		if _, ok := fromProducts[product.ProductID]; ok {
+			diff.Lines = append(diff.Lines, lineDiff(types.LineChangeRemoved, product, types.Product{ProductID: product.ProductID}))
+		}
+	}
+
+	return diff
+}
+
+func lineDiff(change types.LineChange, from types.Product, to types.Product) types.LineDiff {
+	return types.LineDiff{
+		ProductID:   to.ProductID,
+		Change:      change,
+		Quantity:    types.QuantityDiff{From: from.Quantity, To: to.Quantity},
+		Amount:      types.AmountDiff{From: from.Amount, To: to.Amount},
+		TotalAmount: types.AmountDiff{From: from.TotalAmount, To: to.TotalAmount},
+	}
+}
@@ -0,0 +1,201 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/share"
+	"app/internal/quote/types"
+)
+
+const defaultShareTTL = 7 * 24 * time.Hour
+
+// Share issues read-only links to the revisions of the quotes of a customer and resolves them for anyone
+// holding the link.
+type Share struct {
+	repository quoteRepository
+	shares     shareRepository
+	signer     shareSigner
+	config     Config
+}
+
+func NewShare(repository quoteRepository, shares shareRepository, signer shareSigner, config Config) *Share {
+	if config.ShareTTL == 0 {
+		config.ShareTTL = defaultShareTTL
+	}
+
+	return &Share{
+		repository: repository,
+		shares:     shares,
+		signer:     signer,
+		config:     config,
+	}
+}
+
+// CreateShare issues a read-only link to a revision of a quote of the customer, the latest revision when none is
+// asked for. The returned token is the link, it is not stored and can not be read again.
+func (s *Share) CreateShare(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, create types.ShareCreate) (*types.Share, string, error) {
+	quote, err := findCustomerQuote(ctx, s.repository, customerUUID, quoteUUID)
+	if err != nil {
+		return nil, "", fmt.Errorf("Domain::Share::CreateShare : %w", err)
+	}
+
+	if err := s.validateShareCreate(quote, &create); err != nil {
+		return nil, "", fmt.Errorf("Domain::Share::CreateShare : %w", err)
+	}
+
+	now := time.Now()
+	created := &types.Share{
+		UUID:       uuid.New(),
+		QuoteID:    quote.UUID,
+		CustomerID: quote.CustomerID,
+		Revision:   create.Revision,
+		CreatedAt:  now,
+		CreatedBy:  actorFromContext(ctx),
+		ExpiresAt:  now.Add(create.TTL),
+	}
+
+	token, err := s.signer.Sign(share.Claims{
+		ShareID:   created.UUID,
+		QuoteID:   created.QuoteID,
+		Revision:  created.Revision,
+		ExpiresAt: created.ExpiresAt,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("Domain::Share::CreateShare : %w", err)
+	}
+
+	if err := s.shares.SaveShare(ctx, created); err != nil {
+		return nil, "", fmt.Errorf("Domain::Share::CreateShare : %w", err)
+	}
+
+	return created, token, nil
+}
+
+// ListShares returns the shares of a quote of the customer, revoked and expired ones included.
+func (s *Share) ListShares(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) ([]*types.Share, error) {
+	if _, err := findCustomerQuote(ctx, s.repository, customerUUID, quoteUUID); err != nil {
+		return nil, fmt.Errorf("Domain::Share::ListShares : %w", err)
+	}
+
+	shares, err := s.shares.FindShares(ctx, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Share::ListShares : %w", err)
+	}
+
+	return shares, nil
+}
+
+// RevokeShare stops the link from resolving, revoking a revoked share changes nothing.
+func (s *Share) RevokeShare(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, shareUUID uuid.UUID) error {
+	revoked, err := s.loadShare(ctx, customerUUID, quoteUUID, shareUUID)
+	if err != nil {
+		return fmt.Errorf("Domain::Share::RevokeShare : %w", err)
+	}
+
+	if revoked.Revoked() {
+		return nil
+	}
+
+	revoked.RevokedAt = time.Now()
+	if err := s.shares.SaveShare(ctx, revoked); err != nil {
+		return fmt.Errorf("Domain::Share::RevokeShare : %w", err)
+	}
+
+	return nil
+}
+
+// ListShareAccesses returns the requests which resolved the link, oldest first.
+func (s *Share) ListShareAccesses(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, shareUUID uuid.UUID) ([]types.ShareAccess, error) {
+	if _, err := s.loadShare(ctx, customerUUID, quoteUUID, shareUUID); err != nil {
+		return nil, fmt.Errorf("Domain::Share::ListShareAccesses : %w", err)
+	}
+
+	accesses, err := s.shares.FindShareAccesses(ctx, shareUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Share::ListShareAccesses : %w", err)
+	}
+
+	return accesses, nil
+}
+
+// ResolveShare returns the shared revision of the link token and records the access. Links of revoked and
+// expired shares do not resolve.
+func (s *Share) ResolveShare(ctx context.Context, token string, access types.ShareAccess) (*types.Share, *types.QuoteRevision, error) {
+	claims, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", err)
+	}
+
+	resolved, err := s.shares.FindShareByID(ctx, claims.ShareID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", err)
+	}
+
+	if resolved.QuoteID != claims.QuoteID || resolved.Revision != claims.Revision {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", share.ErrTokenInvalid)
+	}
+
+	now := time.Now()
+	if resolved.Revoked() {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", types.ErrShareRevoked)
+	}
+	if resolved.Expired(now) {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", types.ErrShareExpired)
+	}
+
+	revision, err := s.repository.FindRevision(ctx, resolved.QuoteID, resolved.Revision)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", err)
+	}
+
+	access.ShareID = resolved.UUID
+	access.AccessedAt = now
+	if err := s.shares.RecordShareAccess(ctx, access); err != nil {
+		return nil, nil, fmt.Errorf("Domain::Share::ResolveShare : %w", err)
+	}
+
+	return resolved, revision, nil
+}
+
+// loadShare returns the share of the quote of the customer, a share of another quote is not found.
+func (s *Share) loadShare(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, shareUUID uuid.UUID) (*types.Share, error) {
+	found, err := s.shares.FindShareByID(ctx, shareUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if found.QuoteID != quoteUUID || found.CustomerID != customerUUID {
+		return nil, types.ErrShareNotFound
+	}
+
+	return found, nil
+}
+
+func (s *Share) validateShareCreate(quote *types.Quote, create *types.ShareCreate) error {
+	validation := &types.ValidationError{}
+
+	if create.Revision == 0 {
+		create.Revision = quote.Revision
+	}
+	if quote.Revision < 1 {
+		validation.Add("revision", "quote has no saved revision to share yet")
+	} else if create.Revision < 1 || create.Revision > quote.Revision {
+		validation.Add("revision", fmt.Sprintf("revision must be between 1 and %d", quote.Revision))
+	}
+
+	if create.TTL == 0 {
+		create.TTL = s.config.ShareTTL
+	}
+	if create.TTL < 0 {
+		validation.Add("expires_in", "expiry must be in the future")
+	}
+	if s.config.ShareMaxTTL > 0 && create.TTL > s.config.ShareMaxTTL {
+		validation.Add("expires_in", fmt.Sprintf("link can not be valid longer than %d hours", int(s.config.ShareMaxTTL.Hours())))
+	}
+
+	return validation.Err()
+}
@@ -0,0 +1,207 @@
+package domain_test
+
+import (
+	"app/internal/quote/share"
+	"app/internal/quote/types"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestShareCreateShare(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Revision = 4
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	var saved *types.Share
+	tc.shares.EXPECT().
+		SaveShare(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s *types.Share) error {
+			saved = s
+			return nil
+		})
+
+	// act
+	created, token, err := tc.shareService.CreateShare(context.Background(), customerUUID, quote.UUID, types.ShareCreate{})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Same(t, saved, created)
+	assert.Equal(t, quote.UUID, created.QuoteID)
+	assert.Equal(t, customerUUID, created.CustomerID)
+	assert.Equal(t, 4, created.Revision)
+	assert.WithinDuration(t, time.Now().Add(7*24*time.Hour), created.ExpiresAt, time.Minute)
+
+	claims, err := tc.signer.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, created.UUID, claims.ShareID)
+	assert.Equal(t, 4, claims.Revision)
+}
+
+func TestShareCreateShareInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Revision = 2
+
+	tc.repository.EXPECT().
+		FindByID(gomock.Any(), gomock.Eq(quote.UUID)).
+		Return(quote, nil)
+
+	// act
+	created, token, err := tc.shareService.CreateShare(context.Background(), customerUUID, quote.UUID, types.ShareCreate{
+		Revision: 3,
+		TTL:      31 * 24 * time.Hour,
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, created)
+	assert.Empty(t, token)
+	assert.Equal(t, []types.FieldError{
+		{Field: "revision", Message: "revision must be between 1 and 2"},
+		{Field: "expires_in", Message: "link can not be valid longer than 720 hours"},
+	}, validationError.Fields)
+}
+
+func TestShareResolveShare(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	shared := &types.Share{
+		UUID:      uuid.New(),
+		QuoteID:   uuid.New(),
+		Revision:  2,
+		ExpiresAt: time.Now().Add(time.Hour),
+	}
+	token := newTestShareToken(t, tc, shared)
+	revision := &types.QuoteRevision{QuoteID: shared.QuoteID, Number: 2}
+
+	tc.shares.EXPECT().
+		FindShareByID(gomock.Any(), gomock.Eq(shared.UUID)).
+		Return(shared, nil)
+	tc.repository.EXPECT().
+		FindRevision(gomock.Any(), gomock.Eq(shared.QuoteID), gomock.Eq(2)).
+		Return(revision, nil)
+
+	var recorded types.ShareAccess
+	tc.shares.EXPECT().
+		RecordShareAccess(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, access types.ShareAccess) error {
+			recorded = access
+			return nil
+		})
+
+	// act
+	resolved, actual, err := tc.shareService.ResolveShare(context.Background(), token, types.ShareAccess{IP: "203.0.113.7", UserAgent: "curl"})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, shared, resolved)
+	assert.Equal(t, revision, actual)
+	assert.Equal(t, shared.UUID, recorded.ShareID)
+	assert.Equal(t, "203.0.113.7", recorded.IP)
+	assert.False(t, recorded.AccessedAt.IsZero())
+}
+
+func TestShareResolveShareDenied(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name     string
+		stored   func(shared types.Share) types.Share
+		expected error
+	}{
+		{
+			name:     "revoked",
+			stored:   func(shared types.Share) types.Share { shared.RevokedAt = now; return shared },
+			expected: types.ErrShareRevoked,
+		},
+		{
+			name:     "expired",
+			stored:   func(shared types.Share) types.Share { shared.ExpiresAt = now.Add(-time.Minute); return shared },
+			expected: types.ErrShareExpired,
+		},
+		{
+			name:     "other revision",
+			stored:   func(shared types.Share) types.Share { shared.Revision = 1; return shared },
+			expected: share.ErrTokenInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tc := newTestUnitQuote(ctrl)
+			shared := types.Share{UUID: uuid.New(), QuoteID: uuid.New(), Revision: 2, ExpiresAt: now.Add(time.Hour)}
+			token := newTestShareToken(t, tc, &shared)
+			stored := tt.stored(shared)
+
+			tc.shares.EXPECT().
+				FindShareByID(gomock.Any(), gomock.Eq(shared.UUID)).
+				Return(&stored, nil)
+
+			// act
+			resolved, revision, err := tc.shareService.ResolveShare(context.Background(), token, types.ShareAccess{})
+
+			// assert
+			assert.ErrorIs(t, err, tt.expected)
+			assert.Nil(t, resolved)
+			assert.Nil(t, revision)
+		})
+	}
+}
+
+func TestShareRevokeShareOtherQuote(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	shared := &types.Share{UUID: uuid.New(), QuoteID: uuid.New(), CustomerID: customerUUID, Revision: 1}
+
+	tc.shares.EXPECT().
+		FindShareByID(gomock.Any(), gomock.Eq(shared.UUID)).
+		Return(shared, nil)
+
+	// act
+	err := tc.shareService.RevokeShare(context.Background(), customerUUID, uuid.New(), shared.UUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrShareNotFound)
+}
+
+func newTestShareToken(t *testing.T, tc *testUnitQuote, shared *types.Share) string {
+	token, err := tc.signer.Sign(share.Claims{
+		ShareID:   shared.UUID,
+		QuoteID:   shared.QuoteID,
+		Revision:  shared.Revision,
+		ExpiresAt: shared.ExpiresAt,
+	})
+	assert.NoError(t, err)
+
+	return token
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"app/internal/catalog"
+	"app/internal/quote/types"
+)
+
+const maxTemplateNameLength = 100
+
+// Template manages the quote templates staff prepare for the customers. Applying a template changes a draft,
+// it is done by the Quote service.
+type Template struct {
+	templates templateRepository
+	catalog   catalogClient
+	config    Config
+}
+
+func NewTemplate(templates templateRepository, catalog catalogClient, config Config) *Template {
+	return &Template{
+		templates: templates,
+		catalog:   catalog,
+		config:    config,
+	}
+}
+
+// ListTemplates returns every quote template ordered by its name.
+func (t *Template) ListTemplates(ctx context.Context) ([]*types.Template, error) {
+	templates, err := t.templates.FindTemplates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Template::ListTemplates : %w", err)
+	}
+
+	return templates, nil
+}
+
+func (t *Template) LoadTemplate(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error) {
+	template, err := t.templates.FindTemplateByID(ctx, templateUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Template::LoadTemplate : %w", err)
+	}
+
+	return template, nil
+}
+
+// CreateTemplate stores the name, description and lines of the template as a new template.
+func (t *Template) CreateTemplate(ctx context.Context, template *types.Template) (*types.Template, error) {
+	if err := t.validateTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("Domain::Template::CreateTemplate : %w", err)
+	}
+
+	now := time.Now()
+	created := &types.Template{
+		UUID:        uuid.New(),
+		Name:        strings.TrimSpace(template.Name),
+		Description: template.Description,
+		Lines:       template.Lines,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		UpdatedBy:   actorFromContext(ctx),
+	}
+	if err := t.templates.SaveTemplate(ctx, created); err != nil {
+		return nil, fmt.Errorf("Domain::Template::CreateTemplate : %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateTemplate replaces the name, description and lines of the template. Drafts the template was applied to
+// keep their lines.
+func (t *Template) UpdateTemplate(ctx context.Context, templateUUID uuid.UUID, template *types.Template) (*types.Template, error) {
+	updated, err := t.templates.FindTemplateByID(ctx, templateUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Template::UpdateTemplate : %w", err)
+	}
+
+	if err := t.validateTemplate(ctx, template); err != nil {
+		return nil, fmt.Errorf("Domain::Template::UpdateTemplate : %w", err)
+	}
+
+	updated.Name = strings.TrimSpace(template.Name)
+	updated.Description = template.Description
+	updated.Lines = template.Lines
+	updated.UpdatedAt = time.Now()
+	updated.UpdatedBy = actorFromContext(ctx)
+	if err := t.templates.SaveTemplate(ctx, updated); err != nil {
+		return nil, fmt.Errorf("Domain::Template::UpdateTemplate : %w", err)
+	}
+
+	return updated, nil
+}
+
+func (t *Template) DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error {
+	if err := t.templates.DeleteTemplate(ctx, templateUUID); err != nil {
+		return fmt.Errorf("Domain::Template::DeleteTemplate : %w", err)
+	}
+
+	return nil
+}
+
+// ApplyTemplate merges the lines of the template into the customer's draft quote in one change, the quantity
+// of a product already in the draft is added to its line. When the draft does not accept a line no line is
+// added and the returned validation error names the invalid lines, e.g. `lines.2.qty`.
+func (q *Quote) ApplyTemplate(ctx context.Context, customerUUID uuid.UUID, template *types.Template) ([]types.ProductOperationResult, error) {
+	operations := make([]types.ProductOperation, 0, len(template.Lines))
+	for _, line := range template.Lines {
+		operations = append(operations, types.ProductOperation{
+			Type:      types.ProductOperationAdd,
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	results, err := q.applyProductOperations(ctx, customerUUID, operations, templateLineField, &types.ValidationError{})
+	if err != nil {
+		return nil, fmt.Errorf("Domain::Quote::ApplyTemplate : %w", err)
+	}
+
+	return results, nil
+}
+
+// validateTemplate checks the template against the quote rules, so it can be applied to an empty draft,
+// and its products against the catalog.
+func (t *Template) validateTemplate(ctx context.Context, template *types.Template) error {
+	validation := &types.ValidationError{}
+
+	name := strings.TrimSpace(template.Name)
+	if name == "" {
+		validation.Add("name", "name is required")
+	}
+	if utf8.RuneCountInString(name) > maxTemplateNameLength {
+		validation.Add("name", fmt.Sprintf("name can not be longer than %d characters", maxTemplateNameLength))
+	}
+
+	if len(template.Lines) == 0 {
+		validation.Add("lines", "template needs at least one line")
+	}
+	if t.config.MaxLines > 0 && len(template.Lines) > t.config.MaxLines {
+		validation.Add("lines", fmt.Sprintf("template can not have more than %d lines", t.config.MaxLines))
+	}
+
+	seen := make(map[uuid.UUID]bool, len(template.Lines))
+	for i, line := range template.Lines {
+		field := templateLineField(i)
+
+		quantity := &types.ValidationError{}
+		validateQuantity(quantity, line.Quantity, t.config.MaxQuantity)
+		for _, fieldError := range quantity.Fields {
+			validation.Add(field+"."+fieldError.Field, fieldError.Message)
+		}
+
+		if line.ProductID == uuid.Nil {
+			validation.Add(field+".product_id", "product id is required")
+			continue
+		}
+		if seen[line.ProductID] {
+			validation.Add(field+".product_id", "product is already a line of the template")
+			continue
+		}
+		seen[line.ProductID] = true
+
+		if _, err := t.catalog.GetProductByID(ctx, line.ProductID); err != nil {
+			if !errors.Is(err, catalog.ErrProductNotFound) {
+				return err
+			}
+			validation.Add(field+".product_id", "product is not in the catalog")
+		}
+	}
+
+	return validation.Err()
+}
+
+func templateLineField(index int) string {
+	return fmt.Sprintf("lines.%d", index)
+}
@@ -0,0 +1,213 @@
+package domain_test
+
+import (
+	"app/internal/catalog"
+	"app/internal/quote/types"
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestTemplateCreateTemplate(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	productUUID := uuid.New()
+	actor := types.Actor{Type: types.ActorTypeStaff, ID: "staff-1"}
+	ctx := types.WithActor(context.Background(), actor)
+
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 10}, nil)
+
+	var saved *types.Template
+	tc.templates.EXPECT().
+		SaveTemplate(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, template *types.Template) error {
+			saved = template
+			return nil
+		})
+
+	// act
+	template, err := tc.templateService.CreateTemplate(ctx, &types.Template{
+		Name:        " Bathroom starter kit ",
+		Description: "Everything for a small bathroom",
+		Lines:       []types.TemplateLine{{ProductID: productUUID, Quantity: 4}},
+	})
+
+	// assert
+	assert.NoError(t, err)
+	assert.Same(t, saved, template)
+	assert.NotEqual(t, uuid.Nil, template.UUID)
+	assert.Equal(t, "Bathroom starter kit", template.Name)
+	assert.Equal(t, []types.TemplateLine{{ProductID: productUUID, Quantity: 4}}, template.Lines)
+	assert.Equal(t, &actor, template.UpdatedBy)
+	assert.False(t, template.CreatedAt.IsZero())
+}
+
+func TestTemplateCreateTemplateInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	productUUID, unknownUUID := uuid.New(), uuid.New()
+
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(productUUID)).
+		Return(&catalog.Product{ProductID: productUUID, Price: 10}, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Eq(unknownUUID)).
+		Return(nil, catalog.ErrProductNotFound)
+
+	// act
+	template, err := tc.templateService.CreateTemplate(context.Background(), &types.Template{
+		Name: "  ",
+		Lines: []types.TemplateLine{
+			{ProductID: uuid.Nil, Quantity: 1},
+			{ProductID: productUUID, Quantity: 0},
+			{ProductID: productUUID, Quantity: 1},
+			{ProductID: unknownUUID, Quantity: 1},
+		},
+	})
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, template)
+	assert.Equal(t, []types.FieldError{
+		{Field: "name", Message: "name is required"},
+		{Field: "lines", Message: "template can not have more than 2 lines"},
+		{Field: "lines.0.product_id", Message: "product id is required"},
+		{Field: "lines.1.qty", Message: "quantity must be at least 1"},
+		{Field: "lines.2.product_id", Message: "product is already a line of the template"},
+		{Field: "lines.3.product_id", Message: "product is not in the catalog"},
+	}, validationError.Fields)
+}
+
+func TestTemplateUpdateTemplateNotFound(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	templateUUID := uuid.New()
+
+	tc.templates.EXPECT().
+		FindTemplateByID(gomock.Any(), gomock.Eq(templateUUID)).
+		Return(nil, types.ErrTemplateNotFound)
+
+	// act
+	template, err := tc.templateService.UpdateTemplate(context.Background(), templateUUID, &types.Template{Name: "Kit"})
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrTemplateNotFound)
+	assert.Nil(t, template)
+}
+
+func TestQuoteApplyTemplate(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	existingUUID, addedUUID := uuid.New(), uuid.New()
+
+	template := &types.Template{
+		UUID: uuid.New(),
+		Name: "Starter kit",
+		Lines: []types.TemplateLine{
+			{ProductID: existingUUID, Quantity: 2},
+			{ProductID: addedUUID, Quantity: 1},
+		},
+	}
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Number = "Q-2026-000001"
+	quote.Products = []types.Product{{ProductID: existingUUID, Quantity: 3}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+	tc.catalogClient.EXPECT().
+		GetProductByID(gomock.Any(), gomock.Any()).
+		Return(&catalog.Product{Price: 10, TaxRateID: "standard"}, nil).
+		Times(3)
+	tc.taxClient.EXPECT().
+		CalculateTaxes(gomock.Any(), gomock.Eq("standard"), gomock.Any()).
+		Return(0.0, nil).
+		Times(2)
+	tc.repository.EXPECT().
+		Save(gomock.Any(), gomock.Any()).
+		Return(nil)
+
+	// act
+	results, err := tc.service.ApplyTemplate(context.Background(), customerUUID, template)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ProductOperationResult{
+		{Index: 0, Type: types.ProductOperationAdd, ProductID: existingUUID, Quantity: 5},
+		{Index: 1, Type: types.ProductOperationAdd, ProductID: addedUUID, Quantity: 1},
+	}, results)
+	assert.Equal(t, 60.0, quote.Amount)
+}
+
+func TestQuoteApplyTemplateInvalid(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	customerUUID := uuid.New()
+	productUUID := uuid.New()
+
+	template := &types.Template{
+		UUID:  uuid.New(),
+		Name:  "Starter kit",
+		Lines: []types.TemplateLine{{ProductID: productUUID, Quantity: 10}},
+	}
+
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Products = []types.Product{{ProductID: productUUID, Quantity: 95}}
+
+	tc.repository.EXPECT().
+		FindByCustomerAndStatus(gomock.Any(), gomock.Eq(customerUUID), gomock.Eq(types.QuoteStatusDraft)).
+		Return(quote, nil)
+
+	// act
+	results, err := tc.service.ApplyTemplate(context.Background(), customerUUID, template)
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, results)
+	assert.Equal(t, "lines.0.qty", validationError.Fields[0].Field)
+	assert.Equal(t, []types.Product{{ProductID: productUUID, Quantity: 95}}, quote.Products)
+}
+
+func TestTemplateLoadTemplateNotFound(t *testing.T) {
+	// arrange
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	tc := newTestUnitQuote(ctrl)
+	templateUUID := uuid.New()
+
+	tc.templates.EXPECT().
+		FindTemplateByID(gomock.Any(), gomock.Eq(templateUUID)).
+		Return(nil, types.ErrTemplateNotFound)
+
+	// act
+	template, err := tc.templateService.LoadTemplate(context.Background(), templateUUID)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrTemplateNotFound)
+	assert.Nil(t, template)
+}
@@ -0,0 +1,102 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+const minQuantity int = 1
+
+func (q *Quote) validateProductAdd(quote *types.Quote, product *types.ProductAdd) error {
+	validation := &types.ValidationError{}
+
+	if product.ProductID == uuid.Nil {
+		validation.Add("product_id", "product id is required")
+	}
+	validateQuantity(validation, product.Quantity, q.config.MaxQuantity)
+
+	existing, err := findProduct(quote, product.ProductID)
+	switch {
+	case err != nil && q.config.MaxLines > 0 && len(quote.Products) >= q.config.MaxLines:
+		validation.Add("product_id", fmt.Sprintf("quote can not have more than %d lines", q.config.MaxLines))
+	case err == nil && product.Quantity >= minQuantity:
+		// the quantity is merged into the existing line of the product
+		if q.config.MaxQuantity > 0 && existing.Quantity+product.Quantity > q.config.MaxQuantity {
+			validation.Add("qty", fmt.Sprintf("quantity of the line would exceed %d", q.config.MaxQuantity))
+		}
+	}
+
+	return validation.Err()
+}
+
+func (q *Quote) validateProductUpdate(product *types.ProductUpdate) error {
+	validation := &types.ValidationError{}
+	validateQuantity(validation, product.Quantity, q.config.MaxQuantity)
+
+	return validation.Err()
+}
+
+// validateQuantity checks the quantity of a line, zero maxQuantity means no limit.
+func validateQuantity(validation *types.ValidationError, quantity int, maxQuantity int) {
+	if quantity < minQuantity {
+		validation.Add("qty", fmt.Sprintf("quantity must be at least %d", minQuantity))
+	}
+	if maxQuantity > 0 && quantity > maxQuantity {
+		validation.Add("qty", fmt.Sprintf("quantity must be at most %d", maxQuantity))
+	}
+}
+
+func validateDiscount(discount *types.ProductDiscount) error {
+	validation := &types.ValidationError{}
+
+	if discount.Discount < 0 || discount.Discount > 100 {
+		validation.Add("discount_percent", "discount must be between 0 and 100")
+	}
+
+	return validation.Err()
+}
+
+func validatePriceOverride(override *types.ProductPriceOverride) error {
+	validation := &types.ValidationError{}
+
+	if override.Price < 0 {
+		validation.Add("price", "price can not be negative")
+	}
+
+	return validation.Err()
+}
+
+func validateAddress(address *types.Address) error {
+	validation := &types.ValidationError{}
+
+	if strings.TrimSpace(address.Address) == "" {
+		validation.Add("address", "address is required")
+	}
+	if strings.TrimSpace(address.City) == "" {
+		validation.Add("city", "city is required")
+	}
+	if _, ok := countryCodes[address.Country]; !ok {
+		validation.Add("country", "country must be an ISO 3166-1 alpha-2 code")
+	}
+
+	return validation.Err()
+}
+
+// validatePayment accepts every method when no methods are configured.
+func (q *Quote) validatePayment(payment *types.Payment) error {
+	validation := &types.ValidationError{}
+
+	switch {
+	case payment.PaymentMethod == "":
+		validation.Add("payment_method", "payment method is required")
+	case len(q.config.PaymentMethods) > 0 && !slices.Contains(q.config.PaymentMethods, payment.PaymentMethod):
+		validation.Add("payment_method", "payment method must be one of "+strings.Join(q.config.PaymentMethods, ", "))
+	}
+
+	return validation.Err()
+}
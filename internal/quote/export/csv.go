@@ -0,0 +1,120 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"app/internal/quote/types"
+)
+
+// csvHeader are the columns of the CSV export, every quote line is a row repeating the quote columns.
+// A quote without lines is a single row with empty line columns.
+var csvHeader = []string{
+	"quote_id", "quote_number", "customer_id", "status", "revision", "updated_at",
+	"quote_amount", "quote_tax_amount", "quote_total_amount",
+	"product_id", "qty", "discount_percent", "amount", "tax_amount", "total_amount",
+}
+
+// csvFormulaPrefixes start the cells a spreadsheet evaluates.
+const csvFormulaPrefixes = "=+-@\t\r"
+
+type csvWriter struct {
+	writer *csv.Writer
+	header bool
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{
+		writer: csv.NewWriter(w),
+	}
+}
+
+func (c *csvWriter) Write(quote *types.Quote) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	row := []string{
+		quote.UUID.String(),
+		csvText(quote.Number),
+		quote.CustomerID.String(),
+		csvText(string(quote.Status)),
+		strconv.Itoa(quote.Revision),
+		quote.UpdatedAt.UTC().Format(time.RFC3339),
+		formatAmount(quote.Amount),
+		formatAmount(quote.TaxAmount),
+		formatAmount(quote.TotalAmount),
+	}
+
+	if len(quote.Products) == 0 {
+		if err := c.writer.Write(append(row, "", "", "", "", "", "")); err != nil {
+			return fmt.Errorf("Export::CSV::Write : %w", err)
+		}
+	}
+	for _, product := range quote.Products {
+		line := append(row[:len(row):len(row)],
+			product.ProductID.String(),
+			strconv.Itoa(product.Quantity),
+			strconv.FormatFloat(product.Discount, 'f', -1, 64),
+			formatAmount(product.Amount),
+			formatAmount(product.TaxAmount),
+			formatAmount(product.TotalAmount),
+		)
+		if err := c.writer.Write(line); err != nil {
+			return fmt.Errorf("Export::CSV::Write : %w", err)
+		}
+	}
+
+	// every quote is flushed, so the export is streamed quote by quote
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return fmt.Errorf("Export::CSV::Write : %w", err)
+	}
+
+	return nil
+}
+
+// Close writes the header of an empty export.
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.writer.Flush()
+	if err := c.writer.Error(); err != nil {
+		return fmt.Errorf("Export::CSV::Close : %w", err)
+	}
+
+	return nil
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+	c.header = true
+
+	if err := c.writer.Write(csvHeader); err != nil {
+		return fmt.Errorf("Export::CSV::Write : %w", err)
+	}
+
+	return nil
+}
+
+// csvText keeps a spreadsheet from reading a text cell as a formula, e.g. `=HYPERLINK(...)`, by prefixing a
+// leading formula character with an apostrophe. Amounts are written as they are, their sign is no formula.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune(csvFormulaPrefixes, rune(value[0])) {
+		return "'" + value
+	}
+
+	return value
+}
+
+func formatAmount(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', 2, 64)
+}
@@ -0,0 +1,120 @@
+// Package export writes quotes in the formats used by finance systems. The writers encode one quote at a time,
+// so a large set of quotes is streamed without being held in memory.
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"app/internal/quote/types"
+)
+
+type Format string
+
+const (
+	FormatCSV    Format = "csv"
+	FormatNDJSON Format = "ndjson"
+	FormatUBL    Format = "ubl"
+)
+
+var ErrUnsupportedFormat = errors.New("export format is not supported")
+
+type (
+	Config struct {
+		// SellerName is the supplier party of UBL documents.
+		SellerName string
+		Currency   string
+		// ValidityDays is the number of days the quote is valid after its last change.
+		ValidityDays int
+		// Timeout is how long streaming a set of quotes may take, it replaces the timeout of other requests.
+		Timeout time.Duration
+	}
+
+	// Writer encodes quotes one after the other, Close completes the export.
+	Writer interface {
+		Write(quote *types.Quote) error
+		Close() error
+	}
+)
+
+// ParseFormat reads the format name, the empty name is CSV.
+func ParseFormat(name string) (Format, error) {
+	switch format := Format(strings.ToLower(name)); format {
+	case "":
+		return FormatCSV, nil
+	case FormatCSV, FormatNDJSON, FormatUBL:
+		return format, nil
+	}
+
+	return "", fmt.Errorf("Export::ParseFormat : %w: %q", ErrUnsupportedFormat, name)
+}
+
+// ContentType is the media type of an export, a set of UBL documents is exported as a zip archive.
+func ContentType(format Format, set bool) string {
+	switch format {
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	case FormatUBL:
+		if set {
+			return "application/zip"
+		}
+		return "application/xml"
+	}
+
+	return "text/csv"
+}
+
+// Extension is the file extension of an export.
+func Extension(format Format, set bool) string {
+	switch format {
+	case FormatNDJSON:
+		return "ndjson"
+	case FormatUBL:
+		if set {
+			return "zip"
+		}
+		return "xml"
+	}
+
+	return "csv"
+}
+
+// NewWriter returns the writer of a set of quotes in the format.
+func NewWriter(w io.Writer, format Format, config Config) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatUBL:
+		return newUBLArchiveWriter(w, config), nil
+	}
+
+	return nil, fmt.Errorf("Export::NewWriter : %w: %q", ErrUnsupportedFormat, format)
+}
+
+// WriteQuote exports a single quote, in UBL as a single Quotation document.
+func WriteQuote(w io.Writer, format Format, config Config, quote *types.Quote) error {
+	if format == FormatUBL {
+		if err := writeUBL(w, config, quote); err != nil {
+			return fmt.Errorf("Export::WriteQuote : %w", err)
+		}
+		return nil
+	}
+
+	writer, err := NewWriter(w, format, config)
+	if err != nil {
+		return fmt.Errorf("Export::WriteQuote : %w", err)
+	}
+	if err := writer.Write(quote); err != nil {
+		return fmt.Errorf("Export::WriteQuote : %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("Export::WriteQuote : %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,194 @@
+package export_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"app/internal/quote/export"
+	"app/internal/quote/types"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+var testConfig = export.Config{
+	SellerName:   "Meisterwerk GmbH",
+	Currency:     "EUR",
+	ValidityDays: 30,
+}
+
+func assertGolden(t *testing.T, name string, actual []byte) {
+	path := filepath.Join("testdata", name)
+	if *update {
+		assert.NoError(t, os.WriteFile(path, actual, 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
+
+func newTestQuotes() []*types.Quote {
+	full := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.MustParse("3c2d1e0f-9a8b-4c7d-8e6f-5a4b3c2d1e0f"))
+	full.Number = "Q-2026-000123"
+	full.UpdatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	full.Revision = 4
+	full.Status = types.QuoteStatusDone
+	full.Address = &types.Address{Address: "Unter den Linden 1", City: "Berlin", Country: "DE"}
+	full.Payment = &types.Payment{PaymentMethod: "bank_transfer"}
+	full.Products = []types.Product{
+		{
+			ProductID:   uuid.MustParse("f2d6a7a2-5a1b-4c47-8c1e-0a9b8c7d6e5f"),
+			Quantity:    2,
+			Amount:      200,
+			TaxAmount:   38,
+			TotalAmount: 238,
+		},
+		{
+			ProductID:   uuid.MustParse("0e4d3c2b-1a09-4f8e-b7d6-c5b4a3928170"),
+			Quantity:    1,
+			Discount:    12.5,
+			Amount:      87.5,
+			TaxAmount:   16.63,
+			TotalAmount: 104.13,
+		},
+	}
+	full.Amount, full.TaxAmount, full.TotalAmount = 287.5, 54.63, 342.13
+
+	empty := types.NewQuote(uuid.MustParse("9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a"), uuid.MustParse("3c2d1e0f-9a8b-4c7d-8e6f-5a4b3c2d1e0f"))
+	empty.UpdatedAt = time.Date(2026, 1, 3, 0, 0, 0, 0, time.UTC)
+
+	return []*types.Quote{full, empty}
+}
+
+func TestWriterFormats(t *testing.T) {
+	tests := []struct {
+		format export.Format
+		golden string
+	}{
+		{format: export.FormatCSV, golden: "quotes.golden.csv"},
+		{format: export.FormatNDJSON, golden: "quotes.golden.ndjson"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			// arrange
+			var output bytes.Buffer
+			writer, err := export.NewWriter(&output, tt.format, testConfig)
+			assert.NoError(t, err)
+
+			// act
+			for _, quote := range newTestQuotes() {
+				assert.NoError(t, writer.Write(quote))
+			}
+			err = writer.Close()
+
+			// assert
+			assert.NoError(t, err)
+			assertGolden(t, tt.golden, output.Bytes())
+		})
+	}
+}
+
+func TestWriteQuoteUBL(t *testing.T) {
+	// arrange
+	var output bytes.Buffer
+
+	// act
+	err := export.WriteQuote(&output, export.FormatUBL, testConfig, newTestQuotes()[0])
+
+	// assert
+	assert.NoError(t, err)
+	assertGolden(t, "quote.golden.xml", output.Bytes())
+}
+
+func TestWriterUBLArchive(t *testing.T) {
+	// arrange
+	var output bytes.Buffer
+	writer, err := export.NewWriter(&output, export.FormatUBL, testConfig)
+	assert.NoError(t, err)
+	quotes := newTestQuotes()
+
+	// act
+	for _, quote := range quotes {
+		assert.NoError(t, writer.Write(quote))
+	}
+	err = writer.Close()
+
+	// assert
+	assert.NoError(t, err)
+
+	archive, err := zip.NewReader(bytes.NewReader(output.Bytes()), int64(output.Len()))
+	assert.NoError(t, err)
+	assert.Len(t, archive.File, 2)
+	assert.Equal(t, "quote-7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d.xml", archive.File[0].Name)
+	assert.Equal(t, "quote-9d8c7b6a-5f4e-4d3c-8b2a-1f0e9d8c7b6a.xml", archive.File[1].Name)
+
+	file, err := archive.File[0].Open()
+	assert.NoError(t, err)
+	document, err := io.ReadAll(file)
+	assert.NoError(t, err)
+	assertGolden(t, "quote.golden.xml", document)
+}
+
+func TestWriterCSVEmpty(t *testing.T) {
+	// arrange
+	var output bytes.Buffer
+	writer, err := export.NewWriter(&output, export.FormatCSV, testConfig)
+	assert.NoError(t, err)
+
+	// act
+	err = writer.Close()
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, "quote_id,quote_number,customer_id,status,revision,updated_at,quote_amount,quote_tax_amount,quote_total_amount,"+
+		"product_id,qty,discount_percent,amount,tax_amount,total_amount\n", output.String())
+}
+
+func TestWriterCSVFormulaCells(t *testing.T) {
+	// arrange
+	quote := types.NewQuote(uuid.New(), uuid.New())
+	quote.Number = `=HYPERLINK("https://example.com","Q-1")`
+	quote.Status = "@SUM(1+1)"
+	quote.Amount = -5
+
+	var output bytes.Buffer
+	writer, err := export.NewWriter(&output, export.FormatCSV, testConfig)
+	assert.NoError(t, err)
+
+	// act
+	assert.NoError(t, writer.Write(quote))
+	err = writer.Close()
+
+	// assert
+	assert.NoError(t, err)
+	rows, err := csv.NewReader(&output).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 2)
+	assert.Equal(t, `'=HYPERLINK("https://example.com","Q-1")`, rows[1][1])
+	assert.Equal(t, "'@SUM(1+1)", rows[1][3])
+	assert.Equal(t, "-5.00", rows[1][6])
+}
+
+func TestParseFormat(t *testing.T) {
+	format, err := export.ParseFormat("NDJSON")
+	assert.NoError(t, err)
+	assert.Equal(t, export.FormatNDJSON, format)
+
+	format, err = export.ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, export.FormatCSV, format)
+
+	_, err = export.ParseFormat("xlsx")
+	assert.ErrorIs(t, err, export.ErrUnsupportedFormat)
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+// ndjsonQuote is a line of the NDJSON export, the quote as in the API response with its customer.
+type ndjsonQuote struct {
+	CustomerID uuid.UUID `json:"customer_id"`
+	v1.QuoteResponse
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (n *ndjsonWriter) Write(quote *types.Quote) error {
+	err := n.encoder.Encode(ndjsonQuote{
+		CustomerID:    quote.CustomerID,
+		QuoteResponse: v1.NewQuoteResponse(quote),
+	})
+	if err != nil {
+		return fmt.Errorf("Export::NDJSON::Write : %w", err)
+	}
+
+	return nil
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
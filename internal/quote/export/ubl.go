@@ -0,0 +1,260 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"app/internal/quote/types"
+)
+
+const (
+	ublVersion      string = "2.1"
+	ublNamespace    string = "urn:oasis:names:specification:ubl:schema:xsd:Quotation-2"
+	ublNamespaceCAC string = "urn:oasis:names:specification:ubl:schema:xsd:CommonAggregateComponents-2"
+	ublNamespaceCBC string = "urn:oasis:names:specification:ubl:schema:xsd:CommonBasicComponents-2"
+	// ublUnitCode is the UN/ECE rec 20 code of a piece.
+	ublUnitCode string = "C62"
+)
+
+// ublPaymentMeansCodes maps payment methods to UN/CEFACT 4461 codes, other methods are "1" (not defined).
+var ublPaymentMeansCodes = map[string]string{
+	"bank_transfer": "30",
+	"card":          "48",
+}
+
+// The UBL elements are declared in the order of the OASIS UBL 2.1 Quotation schema.
+type (
+	ublQuotation struct {
+		XMLName             xml.Name           `xml:"Quotation"`
+		Namespace           string             `xml:"xmlns,attr"`
+		NamespaceCAC        string             `xml:"xmlns:cac,attr"`
+		NamespaceCBC        string             `xml:"xmlns:cbc,attr"`
+		UBLVersionID        string             `xml:"cbc:UBLVersionID"`
+		ID                  string             `xml:"cbc:ID"`
+		UUID                string             `xml:"cbc:UUID"`
+		IssueDate           string             `xml:"cbc:IssueDate"`
+		PricingCurrencyCode string             `xml:"cbc:PricingCurrencyCode"`
+		LineCountNumeric    int                `xml:"cbc:LineCountNumeric"`
+		ValidityPeriod      ublPeriod          `xml:"cac:ValidityPeriod"`
+		SellerSupplierParty ublSupplierParty   `xml:"cac:SellerSupplierParty"`
+		BuyerCustomerParty  ublCustomerParty   `xml:"cac:BuyerCustomerParty"`
+		PaymentMeans        *ublPaymentMeans   `xml:"cac:PaymentMeans,omitempty"`
+		TaxTotal            ublTaxTotal        `xml:"cac:TaxTotal"`
+		QuotedMonetaryTotal ublMonetaryTotal   `xml:"cac:QuotedMonetaryTotal"`
+		QuotationLines      []ublQuotationLine `xml:"cac:QuotationLine"`
+	}
+
+	ublPeriod struct {
+		EndDate string `xml:"cbc:EndDate"`
+	}
+
+	ublSupplierParty struct {
+		Party ublParty `xml:"cac:Party"`
+	}
+
+	ublCustomerParty struct {
+		Party ublParty `xml:"cac:Party"`
+	}
+
+	ublParty struct {
+		PartyIdentification *ublIdentification `xml:"cac:PartyIdentification,omitempty"`
+		PartyName           *ublPartyName      `xml:"cac:PartyName,omitempty"`
+		PostalAddress       *ublAddress        `xml:"cac:PostalAddress,omitempty"`
+	}
+
+	ublIdentification struct {
+		ID string `xml:"cbc:ID"`
+	}
+
+	ublPartyName struct {
+		Name string `xml:"cbc:Name"`
+	}
+
+	ublAddress struct {
+		StreetName string     `xml:"cbc:StreetName"`
+		CityName   string     `xml:"cbc:CityName"`
+		Country    ublCountry `xml:"cac:Country"`
+	}
+
+	ublCountry struct {
+		IdentificationCode string `xml:"cbc:IdentificationCode"`
+	}
+
+	ublPaymentMeans struct {
+		PaymentMeansCode ublCode `xml:"cbc:PaymentMeansCode"`
+	}
+
+	ublCode struct {
+		Name  string `xml:"name,attr,omitempty"`
+		Value string `xml:",chardata"`
+	}
+
+	ublTaxTotal struct {
+		TaxAmount ublAmount `xml:"cbc:TaxAmount"`
+	}
+
+	ublMonetaryTotal struct {
+		LineExtensionAmount ublAmount `xml:"cbc:LineExtensionAmount"`
+		TaxExclusiveAmount  ublAmount `xml:"cbc:TaxExclusiveAmount"`
+		TaxInclusiveAmount  ublAmount `xml:"cbc:TaxInclusiveAmount"`
+		PayableAmount       ublAmount `xml:"cbc:PayableAmount"`
+	}
+
+	ublQuotationLine struct {
+		LineItem ublLineItem `xml:"cac:LineItem"`
+	}
+
+	ublLineItem struct {
+		ID                  string      `xml:"cbc:ID"`
+		Quantity            ublQuantity `xml:"cbc:Quantity"`
+		LineExtensionAmount ublAmount   `xml:"cbc:LineExtensionAmount"`
+		TotalTaxAmount      ublAmount   `xml:"cbc:TotalTaxAmount"`
+		Item                ublItem     `xml:"cac:Item"`
+	}
+
+	ublQuantity struct {
+		UnitCode string `xml:"unitCode,attr"`
+		Value    int    `xml:",chardata"`
+	}
+
+	ublAmount struct {
+		CurrencyID string `xml:"currencyID,attr"`
+		Value      string `xml:",chardata"`
+	}
+
+	ublItem struct {
+		SellersItemIdentification ublIdentification `xml:"cac:SellersItemIdentification"`
+	}
+)
+
+// writeUBL writes the quote as UBL 2.1 Quotation document.
+func writeUBL(w io.Writer, config Config, quote *types.Quote) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("Export::UBL::Write : %w", err)
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(newUBLQuotation(config, quote)); err != nil {
+		return fmt.Errorf("Export::UBL::Write : %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("Export::UBL::Write : %w", err)
+	}
+
+	return nil
+}
+
+func newUBLQuotation(config Config, quote *types.Quote) ublQuotation {
+	amount := func(value float64) ublAmount {
+		return ublAmount{CurrencyID: config.Currency, Value: formatAmount(value)}
+	}
+
+	document := ublQuotation{
+		Namespace:           ublNamespace,
+		NamespaceCAC:        ublNamespaceCAC,
+		NamespaceCBC:        ublNamespaceCBC,
+		UBLVersionID:        ublVersion,
+		ID:                  quote.Reference(),
+		UUID:                quote.UUID.String(),
+		IssueDate:           quote.UpdatedAt.Format("2006-01-02"),
+		PricingCurrencyCode: config.Currency,
+		LineCountNumeric:    len(quote.Products),
+		ValidityPeriod: ublPeriod{
+			EndDate: quote.UpdatedAt.AddDate(0, 0, config.ValidityDays).Format("2006-01-02"),
+		},
+		SellerSupplierParty: ublSupplierParty{
+			Party: ublParty{PartyName: &ublPartyName{Name: config.SellerName}},
+		},
+		BuyerCustomerParty: ublCustomerParty{
+			Party: ublParty{PartyIdentification: &ublIdentification{ID: quote.CustomerID.String()}},
+		},
+		TaxTotal: ublTaxTotal{TaxAmount: amount(quote.TaxAmount)},
+		QuotedMonetaryTotal: ublMonetaryTotal{
+			LineExtensionAmount: amount(quote.Amount),
+			TaxExclusiveAmount:  amount(quote.Amount),
+			TaxInclusiveAmount:  amount(quote.TotalAmount),
+			PayableAmount:       amount(quote.TotalAmount),
+		},
+		QuotationLines: make([]ublQuotationLine, 0, len(quote.Products)),
+	}
+
+	if quote.Address != nil {
+		document.BuyerCustomerParty.Party.PostalAddress = &ublAddress{
+			StreetName: quote.Address.Address,
+			CityName:   quote.Address.City,
+			Country:    ublCountry{IdentificationCode: quote.Address.Country},
+		}
+	}
+
+	if quote.Payment != nil {
+		code, ok := ublPaymentMeansCodes[quote.Payment.PaymentMethod]
+		if !ok {
+			code = "1"
+		}
+		document.PaymentMeans = &ublPaymentMeans{
+			PaymentMeansCode: ublCode{Name: quote.Payment.PaymentMethod, Value: code},
+		}
+	}
+
+	for i, product := range quote.Products {
+		document.QuotationLines = append(document.QuotationLines, ublQuotationLine{
+			LineItem: ublLineItem{
+				ID:                  strconv.Itoa(i + 1),
+				Quantity:            ublQuantity{UnitCode: ublUnitCode, Value: product.Quantity},
+				LineExtensionAmount: amount(product.Amount),
+				TotalTaxAmount:      amount(product.TaxAmount),
+				Item: ublItem{
+					SellersItemIdentification: ublIdentification{ID: product.ProductID.String()},
+				},
+			},
+		})
+	}
+
+	return document
+}
+
+// ublArchiveWriter writes a set of quotes as zip archive with a Quotation document per quote.
+type ublArchiveWriter struct {
+	archive *zip.Writer
+	config  Config
+}
+
+func newUBLArchiveWriter(w io.Writer, config Config) *ublArchiveWriter {
+	return &ublArchiveWriter{
+		archive: zip.NewWriter(w),
+		config:  config,
+	}
+}
+
+func (u *ublArchiveWriter) Write(quote *types.Quote) error {
+	file, err := u.archive.CreateHeader(&zip.FileHeader{
+		Name:     fmt.Sprintf("quote-%s.xml", quote.UUID),
+		Method:   zip.Deflate,
+		Modified: quote.UpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("Export::UBL::Write : %w", err)
+	}
+
+	if err := writeUBL(file, u.config, quote); err != nil {
+		return err
+	}
+
+	if err := u.archive.Flush(); err != nil {
+		return fmt.Errorf("Export::UBL::Write : %w", err)
+	}
+
+	return nil
+}
+
+func (u *ublArchiveWriter) Close() error {
+	if err := u.archive.Close(); err != nil {
+		return fmt.Errorf("Export::UBL::Close : %w", err)
+	}
+
+	return nil
+}
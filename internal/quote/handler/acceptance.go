@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+type acceptanceRequest struct {
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	Revision     int    `json:"revision"`
+	TermsVersion string `json:"terms_version"`
+}
+
+// Accept records the customer's acceptance of the terms for the draft, signed by the name and email of the
+// acceptor, and responds with the accepted quote. The draft of the customer afterwards is another one.
+func (q *APIHandler) Accept() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::Accept : %w", err)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("APIHandler::Accept : %w: %w", errBodyRead, err)
+		}
+
+		var request acceptanceRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fmt.Errorf("APIHandler::Accept : %w: %w", errBodyRead, err)
+		}
+
+		quote, err := q.quoteService.Accept(r.Context(), customerID, types.Acceptance{
+			Name:         request.Name,
+			Email:        request.Email,
+			IP:           remoteIP(r),
+			UserAgent:    r.UserAgent(),
+			Revision:     request.Revision,
+			TermsVersion: request.TermsVersion,
+		})
+		if err != nil {
+			return fmt.Errorf("APIHandler::Accept : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewQuoteResponse(quote), http.StatusOK)
+	}
+}
@@ -1,11 +1,11 @@
 package handler
 
 import (
-	"app/internal/quote/types"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -13,33 +13,9 @@ import (
 
 type (
 	BaseHandler func(w http.ResponseWriter, r *http.Request) error
-
-	errorResponse struct {
-		Status  int    `json:"-"`
-		Message string `json:"message"`
-	}
 )
 
 var (
-	errorResponseCodes = map[error]errorResponse{
-		errMissedRequiredParameter: {
-			Status:  http.StatusBadRequest,
-			Message: "missed required parameter",
-		},
-		errInvalidParameter: {
-			Status:  http.StatusBadRequest,
-			Message: "parameter is invalid",
-		},
-		errBodyRead: {
-			Status:  http.StatusBadRequest,
-			Message: "parameter is invalid",
-		},
-		types.ErrQuoteNotFound: {
-			Status:  http.StatusNotFound,
-			Message: "quote not found",
-		},
-	}
-
 	errMissedRequiredParameter = errors.New("missing required parameter")
 	errInvalidParameter        = errors.New("invalid parameter")
 	errBodyRead                = errors.New("invalid body")
@@ -47,29 +23,19 @@ var (
 
 func (fn BaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err := fn(w, r); err != nil {
-		respondError(w, err)
+		respondError(w, r, err)
 	}
 }
 
-func respondError(w http.ResponseWriter, err error) error {
-	for e, apiError := range errorResponseCodes {
-		if errors.Is(err, e) {
-			return respond(w, apiError, apiError.Status)
-		}
-	}
-
-	return respond(w, nil, http.StatusInternalServerError)
-}
-
 // Respond writes the given data to an HTTP response with a status code.
 func respond(w http.ResponseWriter, data interface{}, statusCode int) error {
-	w.WriteHeader(statusCode)
-
 	if data == nil || statusCode == http.StatusNoContent {
+		w.WriteHeader(statusCode)
 		return nil
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
 
 	if err := json.NewEncoder(w).Encode(data); err != nil {
 		return fmt.Errorf("encode: %w", err)
@@ -90,3 +56,40 @@ func getParamUUID(r *http.Request, name string) (uuid.UUID, error) {
 
 	return parameterUUID, nil
 }
+
+func getParam(r *http.Request, name string) (string, error) {
+	parameter := chi.URLParam(r, name)
+	if parameter == "" {
+		return "", errMissedRequiredParameter
+	}
+
+	return parameter, nil
+}
+
+func getParamInt(r *http.Request, name string) (int, error) {
+	parameterStr := chi.URLParam(r, name)
+	if parameterStr == "" {
+		return 0, errMissedRequiredParameter
+	}
+
+	parameterInt, err := strconv.Atoi(parameterStr)
+	if err != nil {
+		return 0, errInvalidParameter
+	}
+
+	return parameterInt, nil
+}
+
+func getQueryInt(r *http.Request, name string) (int, error) {
+	parameterStr := r.URL.Query().Get(name)
+	if parameterStr == "" {
+		return 0, errMissedRequiredParameter
+	}
+
+	parameterInt, err := strconv.Atoi(parameterStr)
+	if err != nil {
+		return 0, errInvalidParameter
+	}
+
+	return parameterInt, nil
+}
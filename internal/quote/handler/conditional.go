@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"app/internal/quote/types"
+)
+
+var (
+	errPreconditionRequired = errors.New("precondition required")
+)
+
+// ConditionalMiddleware passes the entity tags of the If-Match header to the domain, which rejects the change
+// when the draft was modified since the client read it. With requireIfMatch the header is mandatory on changes.
+// It must be used after IdempotencyMiddleware, so a retried change is replayed instead of failing the precondition.
+func ConditionalMiddleware(requireIfMatch bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			etags := parseETags(r.Header.Get("If-Match"), false)
+			if len(etags) == 0 {
+				if requireIfMatch {
+					respondError(w, r, errPreconditionRequired)
+					return
+				}
+
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(types.WithExpectedETags(r.Context(), etags)))
+		})
+	}
+}
+
+// parseETags splits an If-Match or If-None-Match header. The weak comparison used by If-None-Match
+// ignores the W/ prefix, the strong comparison of If-Match keeps it, so weak tags never match.
+func parseETags(header string, weak bool) []string {
+	if header == "" {
+		return nil
+	}
+
+	etags := make([]string, 0, 1)
+	for _, etag := range strings.Split(header, ",") {
+		etag = strings.TrimSpace(etag)
+		if weak {
+			etag = strings.TrimPrefix(etag, "W/")
+		}
+		if etag != "" {
+			etags = append(etags, etag)
+		}
+	}
+
+	return etags
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"app/internal/openapi"
+)
+
+type (
+	contractValidator interface {
+		ValidateRequest(r *http.Request) ([]openapi.Violation, error)
+		ValidateResponse(r *http.Request, status int, header http.Header, body []byte) ([]openapi.Violation, error)
+	}
+
+	// responseRecorder keeps the response until it is checked against the contract.
+	responseRecorder struct {
+		header http.Header
+		status int
+		body   bytes.Buffer
+	}
+)
+
+var (
+	errContractRequest  = errors.New("request does not match the API contract")
+	errContractResponse = errors.New("response does not match the API contract")
+)
+
+// ContractMiddleware rejects requests which do not match Openapi.yaml with a 400 listing every violation.
+// When validateResponses is set (test mode), responses are checked too and a mismatch becomes a 500.
+func ContractMiddleware(validator contractValidator, validateResponses bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			violations, err := validator.ValidateRequest(r)
+			if errors.Is(err, openapi.ErrOperationNotFound) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+			if len(violations) > 0 {
+				respondProblem(w, r, errContractRequest, contractErrors(violations))
+				return
+			}
+
+			if !validateResponses {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			recorder := newResponseRecorder()
+			next.ServeHTTP(recorder, r)
+
+			violations, err = validator.ValidateResponse(r, recorder.status, recorder.header, recorder.body.Bytes())
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+			if len(violations) > 0 {
+				respondProblem(w, r, fmt.Errorf("%w: %v", errContractResponse, violations), contractErrors(violations))
+				return
+			}
+
+			recorder.flush(w)
+		})
+	}
+}
+
+func contractErrors(violations []openapi.Violation) []problemError {
+	errs := make([]problemError, 0, len(violations))
+	for _, violation := range violations {
+		errs = append(errs, problemError{
+			Location: violation.Location,
+			Field:    violation.Field,
+			Message:  violation.Message,
+		})
+	}
+
+	return errs
+}
+
+func newResponseRecorder() *responseRecorder {
+	return &responseRecorder{
+		header: make(http.Header),
+		status: http.StatusOK,
+	}
+}
+
+func (r *responseRecorder) Header() http.Header {
+	return r.header
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	return r.body.Write(data)
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+}
+
+func (r *responseRecorder) flush(w http.ResponseWriter) {
+	for name, values := range r.header {
+		w.Header()[name] = values
+	}
+	w.WriteHeader(r.status)
+	w.Write(r.body.Bytes())
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+const (
+	URLQuoteIDParameter string = "quoteID"
+	pdfContentType      string = "application/pdf"
+)
+
+type (
+	documentService interface {
+		LoadDraftByCustomer(ctx context.Context, customerUUID uuid.UUID) (*types.Quote, error)
+		LoadByID(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error)
+	}
+
+	documentRenderer interface {
+		Render(ctx context.Context, w io.Writer, quote *types.Quote) error
+	}
+
+	DocumentHandler struct {
+		documentService documentService
+		renderer        documentRenderer
+	}
+)
+
+func NewDocumentHandler(documentService documentService, renderer documentRenderer) *DocumentHandler {
+	return &DocumentHandler{
+		documentService: documentService,
+		renderer:        renderer,
+	}
+}
+
+// GetQuoteDocument renders the customer's draft quote as PDF.
+func (h *DocumentHandler) GetQuoteDocument() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocument : %w", err)
+		}
+
+		quote, err := h.documentService.LoadDraftByCustomer(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocument : %w", err)
+		}
+
+		if err := h.respondDocument(w, r, quote); err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocument : %w", err)
+		}
+
+		return nil
+	}
+}
+
+// GetQuoteDocumentByID renders any quote of the customer as PDF, e.g. a processed one.
+func (h *DocumentHandler) GetQuoteDocumentByID() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocumentByID : %w", err)
+		}
+
+		quoteID, err := getParamUUID(r, URLQuoteIDParameter)
+		if err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocumentByID : %w", err)
+		}
+
+		quote, err := h.documentService.LoadByID(r.Context(), customerID, quoteID)
+		if err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocumentByID : %w", err)
+		}
+
+		if err := h.respondDocument(w, r, quote); err != nil {
+			return fmt.Errorf("DocumentHandler::GetQuoteDocumentByID : %w", err)
+		}
+
+		return nil
+	}
+}
+
+// respondDocument renders the whole document before writing, so a failed rendering is answered as an error.
+func (h *DocumentHandler) respondDocument(w http.ResponseWriter, r *http.Request, quote *types.Quote) error {
+	var document bytes.Buffer
+	if err := h.renderer.Render(r.Context(), &document, quote); err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", pdfContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`inline; filename="quote-%s.pdf"`, quote.UUID))
+	w.Header().Set("ETag", quote.DocumentETag())
+	w.WriteHeader(http.StatusOK)
+	_, err := w.Write(document.Bytes())
+
+	return err
+}
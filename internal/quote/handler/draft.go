@@ -0,0 +1,121 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+type draftRequest struct {
+	Name string `json:"name"`
+}
+
+// DraftScopeMiddleware scopes the draft operations of the request to the draft of the quote ID from the URL
+// instead of the active draft, so the single quote handlers serve the quote-scoped routes as well.
+func DraftScopeMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			quoteID, err := getParamUUID(r, URLQuoteIDParameter)
+			if err != nil {
+				respondError(w, r, fmt.Errorf("%w: %s", err, URLQuoteIDParameter))
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(types.WithDraftID(r.Context(), quoteID)))
+		})
+	}
+}
+
+// ListDrafts returns the drafts of the customer with the active draft first.
+func (q *APIHandler) ListDrafts() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ListDrafts : %w", err)
+		}
+
+		drafts, err := q.quoteService.ListDrafts(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ListDrafts : %w", err)
+		}
+
+		return respond(w, v1.NewDraftListResponse(drafts), http.StatusOK)
+	}
+}
+
+// CreateDraft starts a new named draft, which becomes the active draft of the customer.
+func (q *APIHandler) CreateDraft() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::CreateDraft : %w", err)
+		}
+
+		request, err := readDraftRequest(r)
+		if err != nil {
+			return fmt.Errorf("APIHandler::CreateDraft : %w", err)
+		}
+
+		quote, err := q.quoteService.CreateDraft(r.Context(), customerID, request.Name)
+		if err != nil {
+			return fmt.Errorf("APIHandler::CreateDraft : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewQuoteResponse(quote), http.StatusCreated)
+	}
+}
+
+func (q *APIHandler) RenameDraft() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::RenameDraft : %w", err)
+		}
+
+		request, err := readDraftRequest(r)
+		if err != nil {
+			return fmt.Errorf("APIHandler::RenameDraft : %w", err)
+		}
+
+		if err := q.quoteService.RenameDraft(r.Context(), customerID, request.Name); err != nil {
+			return fmt.Errorf("APIHandler::RenameDraft : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+// ActivateDraft makes the draft the active draft, which the single quote routes change from now on.
+func (q *APIHandler) ActivateDraft() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ActivateDraft : %w", err)
+		}
+
+		if err := q.quoteService.ActivateDraft(r.Context(), customerID); err != nil {
+			return fmt.Errorf("APIHandler::ActivateDraft : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+func readDraftRequest(r *http.Request) (*draftRequest, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errBodyRead, err)
+	}
+
+	var request draftRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, fmt.Errorf("%w: %w", errBodyRead, err)
+	}
+
+	return &request, nil
+}
@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/export"
+	"app/internal/quote/types"
+)
+
+const (
+	QueryFormatParameter      string = "format"
+	QueryStatusParameter      string = "status"
+	QueryCustomerIDParameter  string = "customer_id"
+	QueryUpdatedFromParameter string = "updated_from"
+	QueryUpdatedToParameter   string = "updated_to"
+)
+
+type (
+	exportService interface {
+		LoadByID(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error)
+		ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error
+	}
+
+	ExportHandler struct {
+		exportService exportService
+		config        export.Config
+	}
+
+	// exportResponse tracks whether the export started, after that an error can not be answered anymore.
+	exportResponse struct {
+		http.ResponseWriter
+		started bool
+	}
+)
+
+func NewExportHandler(exportService exportService, config export.Config) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+		config:        config,
+	}
+}
+
+// ExportQuote exports a quote of the customer as CSV, NDJSON or UBL Quotation document.
+func (h *ExportHandler) ExportQuote() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuote : %w", err)
+		}
+
+		quoteID, err := getParamUUID(r, URLQuoteIDParameter)
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuote : %w", err)
+		}
+
+		format, err := export.ParseFormat(r.URL.Query().Get(QueryFormatParameter))
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuote : %w", err)
+		}
+
+		quote, err := h.exportService.LoadByID(r.Context(), customerID, quoteID)
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuote : %w", err)
+		}
+
+		setExportHeaders(w, format, false, fmt.Sprintf("quote-%s", quote.UUID))
+		if err := export.WriteQuote(w, format, h.config, quote); err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuote : %w", err)
+		}
+
+		return nil
+	}
+}
+
+// ExportQuotes streams the quotes of the filter, ordered by their last change. An error after the first quote
+// was written aborts the response, so a client never mistakes a truncated export for a complete one.
+func (h *ExportHandler) ExportQuotes() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		format, err := export.ParseFormat(r.URL.Query().Get(QueryFormatParameter))
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuotes : %w", err)
+		}
+
+		filter, err := getQuoteFilter(r)
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuotes : %w", err)
+		}
+
+		response := &exportResponse{ResponseWriter: w}
+		writer, err := export.NewWriter(response, format, h.config)
+		if err != nil {
+			return fmt.Errorf("ExportHandler::ExportQuotes : %w", err)
+		}
+
+		setExportHeaders(w, format, true, fmt.Sprintf("quotes-%s", time.Now().UTC().Format("20060102T150405Z")))
+		err = h.exportService.ScanQuotes(r.Context(), filter, writer.Write)
+		if err == nil {
+			err = writer.Close()
+		}
+		if err != nil {
+			if response.started {
+				log.Printf("Export %s aborted: %v", r.URL.Path, err)
+				panic(http.ErrAbortHandler)
+			}
+
+			w.Header().Del("Content-Disposition")
+			return fmt.Errorf("ExportHandler::ExportQuotes : %w", err)
+		}
+
+		return nil
+	}
+}
+
+func (e *exportResponse) Write(p []byte) (int, error) {
+	e.started = true
+	return e.ResponseWriter.Write(p)
+}
+
+func setExportHeaders(w http.ResponseWriter, format export.Format, set bool, name string) {
+	w.Header().Set("Content-Type", export.ContentType(format, set))
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, name, export.Extension(format, set)))
+}
+
+// getQuoteFilter reads the filter of a quote set from the query, `status` may be repeated.
+func getQuoteFilter(r *http.Request) (types.QuoteFilter, error) {
+	query := r.URL.Query()
+	filter := types.QuoteFilter{}
+
+	for _, status := range query[QueryStatusParameter] {
+		filter.Statuses = append(filter.Statuses, types.QuoteStatus(status))
+	}
+
+	if value := query.Get(QueryCustomerIDParameter); value != "" {
+		customerID, err := uuid.Parse(value)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s", errInvalidParameter, QueryCustomerIDParameter)
+		}
+		filter.CustomerID = customerID
+	}
+
+	for name, bound := range map[string]*time.Time{
+		QueryUpdatedFromParameter: &filter.UpdatedFrom,
+		QueryUpdatedToParameter:   &filter.UpdatedTo,
+	} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %s", errInvalidParameter, name)
+		}
+		*bound = parsed
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+const (
+	QuerySortParameter   string = "sort"
+	QueryCursorParameter string = "cursor"
+	QueryLimitParameter  string = "limit"
+
+	defaultPageLimit int = 20
+	maxPageLimit     int = 100
+)
+
+type (
+	historyService interface {
+		ListQuotes(ctx context.Context, customerUUID uuid.UUID, query types.QuoteQuery) (*types.QuotePage, error)
+		LoadByID(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error)
+	}
+
+	HistoryHandler struct {
+		historyService historyService
+	}
+)
+
+func NewHistoryHandler(historyService historyService) *HistoryHandler {
+	return &HistoryHandler{
+		historyService: historyService,
+	}
+}
+
+// ListQuotes returns a page of the customer's quotes in any status. The next page is requested
+// with the cursor of the response, together with the same filter and sort.
+func (h *HistoryHandler) ListQuotes() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("HistoryHandler::ListQuotes : %w", err)
+		}
+
+		query, err := getQuoteQuery(r)
+		if err != nil {
+			return fmt.Errorf("HistoryHandler::ListQuotes : %w", err)
+		}
+
+		page, err := h.historyService.ListQuotes(r.Context(), customerID, query)
+		if err != nil {
+			return fmt.Errorf("HistoryHandler::ListQuotes : %w", err)
+		}
+
+		var next string
+		if page.Next != nil {
+			next = encodeCursor(*page.Next)
+		}
+
+		return respond(w, v1.NewQuoteListResponse(page.Quotes, next), http.StatusOK)
+	}
+}
+
+// GetQuote returns any quote of the customer, e.g. a processed one.
+func (h *HistoryHandler) GetQuote() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("HistoryHandler::GetQuote : %w", err)
+		}
+
+		quoteID, err := getParamUUID(r, URLQuoteIDParameter)
+		if err != nil {
+			return fmt.Errorf("HistoryHandler::GetQuote : %w", err)
+		}
+
+		quote, err := h.historyService.LoadByID(r.Context(), customerID, quoteID)
+		if err != nil {
+			return fmt.Errorf("HistoryHandler::GetQuote : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewQuoteResponse(quote), http.StatusOK)
+	}
+}
+
+// getQuoteQuery reads the filter, the sort, the cursor and the page size from the query.
+func getQuoteQuery(r *http.Request) (types.QuoteQuery, error) {
+	filter, err := getQuoteFilter(r)
+	if err != nil {
+		return types.QuoteQuery{}, err
+	}
+
+	query := types.QuoteQuery{
+		Filter: filter,
+		Sort:   types.QuoteSort(r.URL.Query().Get(QuerySortParameter)),
+		Limit:  defaultPageLimit,
+	}
+	if query.Sort != "" && !slices.Contains(types.QuoteSorts, query.Sort) {
+		return query, fmt.Errorf("%w: %s", errInvalidParameter, QuerySortParameter)
+	}
+
+	if value := r.URL.Query().Get(QueryCursorParameter); value != "" {
+		cursor, err := decodeCursor(value)
+		if err != nil {
+			return query, fmt.Errorf("%w: %s", errInvalidParameter, QueryCursorParameter)
+		}
+		query.After = &cursor
+	}
+
+	if r.URL.Query().Has(QueryLimitParameter) {
+		limit, err := getQueryInt(r, QueryLimitParameter)
+		if err != nil || limit < 1 || limit > maxPageLimit {
+			return query, fmt.Errorf("%w: %s", errInvalidParameter, QueryLimitParameter)
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// encodeCursor makes the position of a quote an opaque token, the time and the ID of the quote.
+func encodeCursor(cursor types.QuoteCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursor.Time.Format(time.RFC3339Nano) + "|" + cursor.ID.String()))
+}
+
+func decodeCursor(value string) (types.QuoteCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return types.QuoteCursor{}, err
+	}
+
+	at, id, ok := strings.Cut(string(data), "|")
+	if !ok {
+		return types.QuoteCursor{}, errInvalidParameter
+	}
+
+	var cursor types.QuoteCursor
+	if cursor.Time, err = time.Parse(time.RFC3339Nano, at); err != nil {
+		return types.QuoteCursor{}, err
+	}
+	if cursor.ID, err = uuid.Parse(id); err != nil {
+		return types.QuoteCursor{}, err
+	}
+
+	return cursor, nil
+}
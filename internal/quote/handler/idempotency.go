@@ -0,0 +1,155 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"maps"
+	"net/http"
+	"time"
+
+	"app/internal/quote/types"
+)
+
+const (
+	IdempotencyKeyHeader     string = "Idempotency-Key"
+	IdempotentReplayedHeader string = "Idempotent-Replayed"
+	idempotencyKeyMaxLength  int    = 255
+)
+
+type (
+	idempotencyStore interface {
+		// Reserve stores the record unless the key is taken, then the existing record is returned.
+		Reserve(ctx context.Context, record *types.IdempotencyRecord) (*types.IdempotencyRecord, error)
+		Complete(ctx context.Context, record *types.IdempotencyRecord) error
+		Release(ctx context.Context, key string) error
+	}
+)
+
+// IdempotencyMiddleware executes a mutating request sent with an Idempotency-Key header only once.
+// Retries are answered with the stored response, reusing the key for another request is rejected.
+// Server errors, panics and responses which can not be stored release the key, so the request can be retried with it.
+// It must be used after the access middlewares, keys are scoped to the token subject.
+func IdempotencyMiddleware(store idempotencyStore, ttl time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isMutating(r.Method) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > idempotencyKeyMaxLength {
+				respondError(w, r, errInvalidParameter)
+				return
+			}
+
+			fingerprint, err := requestFingerprint(r)
+			if err != nil {
+				respondError(w, r, fmt.Errorf("%w: %w", errBodyRead, err))
+				return
+			}
+
+			now := time.Now()
+			record := &types.IdempotencyRecord{
+				Key:         idempotencyScope(r) + ":" + key,
+				Fingerprint: fingerprint,
+				CreatedAt:   now,
+				ExpiresAt:   now.Add(ttl),
+			}
+
+			existing, err := store.Reserve(r.Context(), record)
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+			if existing != nil {
+				replayIdempotent(w, r, existing, fingerprint)
+				return
+			}
+
+			// the key is released unless the response is stored, also when the handler panics, e.g. an export
+			// aborted with http.ErrAbortHandler, so the request can be retried with the same key
+			completed := false
+			defer func() {
+				if completed {
+					return
+				}
+				if err := store.Release(context.WithoutCancel(r.Context()), record.Key); err != nil {
+					log.Printf("Failed to release idempotency key of %s %s: %v", r.Method, r.URL.Path, err)
+				}
+			}()
+
+			recorder := newResponseRecorder()
+			next.ServeHTTP(recorder, r)
+
+			if recorder.status < http.StatusInternalServerError {
+				record.Completed = true
+				record.StatusCode = recorder.status
+				record.Header = maps.Clone(recorder.header)
+				record.Body = recorder.body.Bytes()
+
+				if err := store.Complete(r.Context(), record); err != nil {
+					log.Printf("Failed to store idempotent response of %s %s: %v", r.Method, r.URL.Path, err)
+				} else {
+					completed = true
+				}
+			}
+
+			recorder.flush(w)
+		})
+	}
+}
+
+func replayIdempotent(w http.ResponseWriter, r *http.Request, record *types.IdempotencyRecord, fingerprint string) {
+	if record.Fingerprint != fingerprint {
+		respondError(w, r, types.ErrIdempotencyKeyReused)
+		return
+	}
+	if !record.Completed {
+		respondError(w, r, types.ErrIdempotencyKeyInProgress)
+		return
+	}
+
+	for name, values := range record.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(record.StatusCode)
+	w.Write(record.Body)
+}
+
+// requestFingerprint hashes the method, path and body, the body stays readable.
+func requestFingerprint(r *http.Request) (string, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return "", err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	hash := sha256.New()
+	hash.Write([]byte(r.Method + " " + r.URL.Path + "\n"))
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func idempotencyScope(r *http.Request) string {
+	if principal, ok := principalFromContext(r.Context()); ok {
+		return principal.Subject
+	}
+
+	return ""
+}
+
+func isMutating(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	}
+
+	return false
+}
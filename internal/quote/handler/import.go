@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/importer"
+)
+
+const importFileField string = "file"
+
+// ImportProducts adds the lines of an uploaded CSV or XLSX file to the quote. The file is sent as the `file`
+// field of a multipart form, its format is taken from the file name. Its size is limited by BodyLimitMiddleware.
+func (q *APIHandler) ImportProducts() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ImportProducts : %w", err)
+		}
+
+		file, header, err := r.FormFile(importFileField)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ImportProducts : %w: %w", errBodyRead, err)
+		}
+		defer file.Close()
+
+		format, err := importer.FormatFromFilename(header.Filename)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ImportProducts : %w", err)
+		}
+
+		lines, err := importer.Parse(file, format)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ImportProducts : %w", err)
+		}
+
+		results, err := q.quoteService.ImportProducts(r.Context(), customerID, lines)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ImportProducts : %w", err)
+		}
+
+		quote, err := q.quoteService.LoadDraftByCustomer(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ImportProducts : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewProductImportResponse(results, lines, quote), http.StatusOK)
+	}
+}
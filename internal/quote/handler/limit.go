@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MaxBodyBytes limits the size of request bodies, the largest body is an uploaded import file.
+const MaxBodyBytes int64 = 10 << 20
+
+var errBodyTooLarge = errors.New("request body is too large")
+
+// limitedBody reports a body exceeding its limit as errBodyTooLarge to every reader of the body.
+type limitedBody struct {
+	io.ReadCloser
+}
+
+// BodyLimitMiddleware rejects request bodies larger than maxBytes with a 413. It must be used before the
+// middlewares reading the whole body, the contract and the idempotency middleware, to bound their memory.
+func BodyLimitMiddleware(maxBytes int64) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.ContentLength > maxBytes {
+				respondError(w, r, errBodyTooLarge)
+				return
+			}
+
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = limitedBody{http.MaxBytesReader(w, r.Body, maxBytes)}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func (b limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		return n, fmt.Errorf("%w: %w", errBodyTooLarge, err)
+	}
+
+	return n, err
+}
@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+const redactedToken string = "[redacted]"
+
+type (
+	// redactingLogFormatter formats the entries like the wrapped formatter once the request is routed, when the
+	// token of a share link is known.
+	redactingLogFormatter struct {
+		formatter middleware.LogFormatter
+	}
+
+	redactingLogEntry struct {
+		formatter middleware.LogFormatter
+		request   *http.Request
+	}
+)
+
+// LoggerMiddleware logs every request like middleware.Logger, but the token of a share link in the path is
+// replaced, as it grants access to the quote to whoever reads the log.
+func LoggerMiddleware(logger middleware.LoggerInterface) func(http.Handler) http.Handler {
+	return middleware.RequestLogger(redactingLogFormatter{
+		formatter: &middleware.DefaultLogFormatter{Logger: logger, NoColor: true},
+	})
+}
+
+func (f redactingLogFormatter) NewLogEntry(r *http.Request) middleware.LogEntry {
+	return &redactingLogEntry{formatter: f.formatter, request: r}
+}
+
+func (e *redactingLogEntry) Write(status, bytes int, header http.Header, elapsed time.Duration, extra interface{}) {
+	e.formatter.NewLogEntry(redactRequest(e.request)).Write(status, bytes, header, elapsed, extra)
+}
+
+func (e *redactingLogEntry) Panic(v interface{}, stack []byte) {
+	e.formatter.NewLogEntry(redactRequest(e.request)).Panic(v, stack)
+}
+
+// redactRequest returns a copy of the request for the log with the token of a share link replaced.
+func redactRequest(r *http.Request) *http.Request {
+	token := shareToken(r)
+	if token == "" {
+		return r
+	}
+
+	redacted := r.WithContext(r.Context())
+	url := *r.URL
+	url.Path = strings.Replace(url.Path, token, redactedToken, 1)
+	url.RawPath = ""
+	redacted.URL = &url
+	redacted.RequestURI = strings.Replace(r.RequestURI, token, redactedToken, 1)
+
+	return redacted
+}
+
+// logPath returns the path of the request for the log without the token of a share link.
+func logPath(r *http.Request) string {
+	if token := shareToken(r); token != "" {
+		return strings.Replace(r.URL.Path, token, redactedToken, 1)
+	}
+
+	return r.URL.Path
+}
+
+// shareToken returns the token of a share link once the request is routed.
+func shareToken(r *http.Request) string {
+	if chi.RouteContext(r.Context()) == nil {
+		return ""
+	}
+
+	return chi.URLParam(r, URLShareTokenParameter)
+}
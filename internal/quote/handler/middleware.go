@@ -4,20 +4,36 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/google/uuid"
+
+	"app/internal/auth"
+	"app/internal/quote/types"
 )
 
 type (
 	customerIDCtx struct{}
+	principalCtx  struct{}
 
 	customerService interface {
 		IsActive(ctx context.Context, customerUUID uuid.UUID) (bool, error)
 	}
+
+	tokenVerifier interface {
+		Verify(ctx context.Context, token string) (*auth.Principal, error)
+	}
+
+	agentAssignments interface {
+		IsAssigned(ctx context.Context, agentID uuid.UUID, customerID uuid.UUID) (bool, error)
+	}
 )
 
 var (
 	errCustomerDisabled = errors.New("customer is disabled")
+	errUnauthorized     = errors.New("unauthorized")
+	errForbidden        = errors.New("forbidden")
+	errPermissionDenied = errors.New("permission denied")
 )
 
 func CustomerCtxMiddleware(customerService customerService) func(http.Handler) http.Handler {
@@ -25,17 +41,17 @@ func CustomerCtxMiddleware(customerService customerService) func(http.Handler) h
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			customerID, err := getParamUUID(r, URLCustomerIDParameter)
 			if err != nil {
-				respondError(w, err)
+				respondError(w, r, err)
 				return
 			}
 
 			active, err := customerService.IsActive(r.Context(), customerID)
 			if err != nil {
-				respondError(w, err)
+				respondError(w, r, err)
 				return
 			}
 			if !active {
-				respondError(w, errCustomerDisabled)
+				respondError(w, r, errCustomerDisabled)
 				return
 			}
 
@@ -45,3 +61,154 @@ func CustomerCtxMiddleware(customerService customerService) func(http.Handler) h
 		})
 	}
 }
+
+// AuthMiddleware authenticates the bearer token of the request and stores the principal in the context.
+func AuthMiddleware(verifier tokenVerifier) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+			if !found || token == "" {
+				w.Header().Set("WWW-Authenticate", "Bearer")
+				respondError(w, r, errUnauthorized)
+				return
+			}
+
+			principal, err := verifier.Verify(r.Context(), token)
+			if err != nil {
+				w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+				respondError(w, r, errors.Join(errUnauthorized, err))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), principalCtx{}, principal)
+			// discounts are also granted by negotiating, so the domain checks the permission for every actor
+			if principal.Can(auth.PermissionQuoteDiscount) {
+				ctx = types.WithDiscountPermission(ctx)
+			}
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// CustomerAccessMiddleware allows the request only when the token subject is the customer from the URL
+// or the principal is staff. It must be used after AuthMiddleware on routes having the customer parameter.
+func CustomerAccessMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := principalFromContext(r.Context())
+			if !ok {
+				respondError(w, r, errUnauthorized)
+				return
+			}
+
+			customerID, err := getParamUUID(r, URLCustomerIDParameter)
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+
+			actor := types.Actor{Type: types.ActorTypeCustomer, ID: principal.Subject}
+			if principal.Subject != customerID.String() {
+				if !principal.HasRole(auth.RoleStaff) {
+					respondError(w, r, errForbidden)
+					return
+				}
+				actor.Type = types.ActorTypeStaff
+			}
+
+			next.ServeHTTP(w, r.WithContext(types.WithActor(r.Context(), actor)))
+		})
+	}
+}
+
+// StaffAccessMiddleware allows the request only when the principal is staff. It must be used after AuthMiddleware.
+func StaffAccessMiddleware() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := principalFromContext(r.Context())
+			if !ok {
+				respondError(w, r, errUnauthorized)
+				return
+			}
+
+			if !principal.HasRole(auth.RoleStaff) {
+				respondError(w, r, errPermissionDenied)
+				return
+			}
+
+			actor := types.Actor{Type: types.ActorTypeStaff, ID: principal.Subject}
+			next.ServeHTTP(w, r.WithContext(types.WithActor(r.Context(), actor)))
+		})
+	}
+}
+
+func principalFromContext(ctx context.Context) (*auth.Principal, bool) {
+	principal, ok := ctx.Value(principalCtx{}).(*auth.Principal)
+	return principal, ok
+}
+
+// AgentAccessMiddleware allows the request only when the token subject is the agent from the URL
+// and the agent is assigned to the customer account. Changes are recorded as made by the agent.
+func AgentAccessMiddleware(assignments agentAssignments) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := principalFromContext(r.Context())
+			if !ok {
+				respondError(w, r, errUnauthorized)
+				return
+			}
+
+			agentID, err := getParamUUID(r, URLAgentIDParameter)
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+
+			customerID, err := getParamUUID(r, URLCustomerIDParameter)
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+
+			if principal.Subject != agentID.String() || !principal.IsAgent() {
+				respondError(w, r, errForbidden)
+				return
+			}
+
+			assigned, err := assignments.IsAssigned(r.Context(), agentID, customerID)
+			if err != nil {
+				respondError(w, r, err)
+				return
+			}
+			if !assigned {
+				respondError(w, r, errForbidden)
+				return
+			}
+
+			actor := types.Actor{Type: types.ActorTypeAgent, ID: agentID.String()}
+
+			next.ServeHTTP(w, r.WithContext(types.WithActor(r.Context(), actor)))
+		})
+	}
+}
+
+// PermissionMiddleware allows the request only when the principal roles grant the permission.
+func PermissionMiddleware(permission auth.Permission) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := principalFromContext(r.Context())
+			if !ok {
+				respondError(w, r, errUnauthorized)
+				return
+			}
+
+			if !principal.Can(permission) {
+				respondError(w, r, errPermissionDenied)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+const URLOfferNumberParameter string = "offerNumber"
+
+type (
+	offerRequest struct {
+		Lines []offerLineRequest `json:"lines"`
+		Note  string             `json:"note"`
+	}
+
+	offerLineRequest struct {
+		ProductID string  `json:"product_id"`
+		Price     float64 `json:"price"`
+		Discount  float64 `json:"discount_percent"`
+	}
+)
+
+// ListOffers returns the negotiation of the draft, or of a quote scoped by its ID in any status.
+func (q *APIHandler) ListOffers() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ListOffers : %w", err)
+		}
+
+		return q.respondNegotiation(w, r, customerID, http.StatusOK)
+	}
+}
+
+// ProposeOffer adds an offer of line prices to the negotiation of the draft, countering the open offer of the
+// other side.
+func (q *APIHandler) ProposeOffer() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ProposeOffer : %w", err)
+		}
+
+		create, err := readOfferRequest(r)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ProposeOffer : %w", err)
+		}
+
+		if err := q.quoteService.ProposeOffer(r.Context(), customerID, create); err != nil {
+			return fmt.Errorf("APIHandler::ProposeOffer : %w", err)
+		}
+
+		return q.respondNegotiation(w, r, customerID, http.StatusCreated)
+	}
+}
+
+// AcceptOffer agrees to the open offer of the other side, the draft is repriced by it.
+func (q *APIHandler) AcceptOffer() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::AcceptOffer : %w", err)
+		}
+
+		number, err := getParamInt(r, URLOfferNumberParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::AcceptOffer : %w", err)
+		}
+
+		if err := q.quoteService.AcceptOffer(r.Context(), customerID, number); err != nil {
+			return fmt.Errorf("APIHandler::AcceptOffer : %w", err)
+		}
+
+		return q.respondNegotiation(w, r, customerID, http.StatusOK)
+	}
+}
+
+func (q *APIHandler) respondNegotiation(w http.ResponseWriter, r *http.Request, customerID uuid.UUID, status int) error {
+	quote, err := q.loadQuote(r.Context(), customerID)
+	if err != nil {
+		return fmt.Errorf("APIHandler::respondNegotiation : %w", err)
+	}
+
+	w.Header().Set("ETag", quote.ETag())
+	return respond(w, v1.NewNegotiationResponse(quote), status)
+}
+
+func readOfferRequest(r *http.Request) (types.OfferCreate, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return types.OfferCreate{}, fmt.Errorf("%w: %w", errBodyRead, err)
+	}
+
+	var request offerRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return types.OfferCreate{}, fmt.Errorf("%w: %w", errBodyRead, err)
+	}
+
+	create := types.OfferCreate{
+		Lines: make([]types.OfferLine, 0, len(request.Lines)),
+		Note:  request.Note,
+	}
+	for _, line := range request.Lines {
+		productID, err := uuid.Parse(line.ProductID)
+		if err != nil {
+			return types.OfferCreate{}, fmt.Errorf("%w: %w", errInvalidParameter, err)
+		}
+
+		create.Lines = append(create.Lines, types.OfferLine{
+			ProductID: productID,
+			Price:     line.Price,
+			Discount:  line.Discount,
+		})
+	}
+
+	return create, nil
+}
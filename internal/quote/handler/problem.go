@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+
+	"app/internal/agent"
+	"app/internal/auth"
+	"app/internal/catalog"
+	"app/internal/customer"
+	"app/internal/order"
+	"app/internal/quote/export"
+	"app/internal/quote/importer"
+	"app/internal/quote/share"
+	"app/internal/quote/types"
+	"app/internal/tax"
+)
+
+const (
+	problemContentType string = "application/problem+json"
+	// problemTypeURIPrefix identifies the problem types of the API, they are not meant to be dereferenced.
+	problemTypeURIPrefix string = "urn:quote-api:problem:"
+)
+
+type (
+	// problem is the RFC 9457 problem details body.
+	problem struct {
+		Type      string         `json:"type"`
+		Title     string         `json:"title"`
+		Status    int            `json:"status"`
+		Detail    string         `json:"detail,omitempty"`
+		Instance  string         `json:"instance,omitempty"`
+		RequestID string         `json:"request_id,omitempty"`
+		Errors    []problemError `json:"errors,omitempty"`
+	}
+
+	// problemError is a single invalid field of the request.
+	problemError struct {
+		Location string `json:"location,omitempty"`
+		Field    string `json:"field,omitempty"`
+		Message  string `json:"message"`
+	}
+
+	problemType struct {
+		err    error
+		status int
+		name   string
+		title  string
+		detail string
+	}
+)
+
+// problemTypes maps errors to problem details. The first matching entry wins, so errors which are joined
+// with or wrap other registered errors must be listed before them.
+var problemTypes = []problemType{
+	{auth.ErrVerifierNotConfigured, http.StatusInternalServerError, "authentication-not-configured", "Authentication Not Configured", "token verification is not configured"},
+	{errUnauthorized, http.StatusUnauthorized, "unauthorized", "Unauthorized", "a valid bearer token is required"},
+	{errForbidden, http.StatusForbidden, "forbidden", "Forbidden", "access to the customer is forbidden"},
+	{errPermissionDenied, http.StatusForbidden, "forbidden", "Forbidden", "roles of the token do not grant the operation"},
+	{errCustomerDisabled, http.StatusForbidden, "customer-disabled", "Customer Disabled", "customer account is disabled"},
+	{errContractRequest, http.StatusBadRequest, "contract-violation", "Contract Violation", "request does not match the API contract"},
+	{errContractResponse, http.StatusInternalServerError, "response-contract-violation", "Response Contract Violation", "response does not match the API contract"},
+	{errMissedRequiredParameter, http.StatusBadRequest, "missing-parameter", "Missing Parameter", "missed required parameter"},
+	{errInvalidParameter, http.StatusBadRequest, "invalid-parameter", "Invalid Parameter", "parameter is invalid"},
+	{errPreconditionRequired, http.StatusPreconditionRequired, "precondition-required", "Precondition Required", "changes require the If-Match header with the entity tag of the quote"},
+	{errBodyTooLarge, http.StatusRequestEntityTooLarge, "body-too-large", "Body Too Large", "request body exceeds the size limit"},
+	{errBodyRead, http.StatusBadRequest, "invalid-body", "Invalid Body", "request body can not be read"},
+	{importer.ErrUnsupportedFormat, http.StatusUnsupportedMediaType, "unsupported-import-format", "Unsupported Import Format", "import files must be CSV or XLSX"},
+	{export.ErrUnsupportedFormat, http.StatusBadRequest, "unsupported-export-format", "Unsupported Export Format", "exports are CSV, NDJSON or UBL"},
+	{importer.ErrInvalidFile, http.StatusUnprocessableEntity, "invalid-import-file", "Invalid Import File", "import file can not be read"},
+
+	{types.ErrQuoteValidation, http.StatusUnprocessableEntity, "validation-failed", "Validation Failed", "quote input is invalid"},
+	{types.ErrQuoteNoPendingPrice, http.StatusConflict, "no-pending-price-override", "No Pending Price Override", "product has no pending price override"},
+	{types.ErrQuoteApprovalPending, http.StatusConflict, "approval-pending", "Approval Pending", "quote has price overrides waiting for approval"},
+	{types.ErrQuoteAcceptanceRequired, http.StatusConflict, "acceptance-required", "Acceptance Required", "quote must be accepted by the customer before it is processed"},
+	{types.ErrQuoteAcceptanceForbidden, http.StatusForbidden, "forbidden", "Forbidden", "only the customer can accept the quote"},
+	{types.ErrOfferNotFound, http.StatusNotFound, "offer-not-found", "Offer Not Found", "quote has no offer of this number"},
+	{types.ErrOfferNotOpen, http.StatusConflict, "offer-not-open", "Offer Not Open", "offer was already accepted or countered"},
+	{types.ErrOfferDiscountForbidden, http.StatusForbidden, "forbidden", "Forbidden", "roles of the token do not grant the operation"},
+	{types.ErrOfferPending, http.StatusConflict, "offer-pending", "Offer Pending", "offer waits for an answer of the other side"},
+	{types.ErrQuoteModified, http.StatusPreconditionFailed, "quote-modified", "Quote Modified", "quote was modified since it was read, load it again"},
+	{types.ErrQuoteUnchangeable, http.StatusConflict, "quote-unchangeable", "Quote Unchangeable", "quote can not be changed anymore"},
+	{types.ErrQuoteVersionConflict, http.StatusConflict, "quote-version-conflict", "Quote Version Conflict", "quote was changed concurrently, retry the request"},
+	{types.ErrQuoteRevisionExists, http.StatusConflict, "quote-version-conflict", "Quote Version Conflict", "quote was changed concurrently, retry the request"},
+	{types.ErrQuoteRevisionNotFound, http.StatusNotFound, "revision-not-found", "Revision Not Found", "quote revision not found"},
+	{types.ErrQuoteProductNotFound, http.StatusNotFound, "product-not-found", "Product Not Found", "product is not part of the quote"},
+	{types.ErrQuoteNotFound, http.StatusNotFound, "quote-not-found", "Quote Not Found", "quote not found"},
+	{types.ErrTemplateNotFound, http.StatusNotFound, "template-not-found", "Template Not Found", "quote template not found"},
+	{types.ErrShareNotFound, http.StatusNotFound, "share-not-found", "Share Not Found", "quote share link not found"},
+	{types.ErrShareExpired, http.StatusGone, "share-expired", "Share Expired", "quote share link has expired"},
+	{types.ErrShareRevoked, http.StatusGone, "share-revoked", "Share Revoked", "quote share link was revoked"},
+	{share.ErrTokenInvalid, http.StatusNotFound, "share-not-found", "Share Not Found", "quote share link not found"},
+	{share.ErrSignerNotConfigured, http.StatusInternalServerError, "share-not-configured", "Share Links Not Configured", "share links are not configured"},
+	{types.ErrIdempotencyKeyReused, http.StatusUnprocessableEntity, "idempotency-key-reused", "Idempotency Key Reused", "idempotency key was already used for another request"},
+	{types.ErrIdempotencyKeyInProgress, http.StatusConflict, "idempotency-key-in-progress", "Request In Progress", "request with the idempotency key is still processed, retry later"},
+	{types.ErrEventTypeUnknown, http.StatusInternalServerError, "quote-history-unreadable", "Quote History Unreadable", "quote history contains an unknown event"},
+
+	{catalog.ErrProductNotFound, http.StatusUnprocessableEntity, "catalog-product-not-found", "Catalog Product Not Found", "product does not exist in the catalog"},
+	{catalog.ErrUnavailable, http.StatusServiceUnavailable, "catalog-unavailable", "Catalog Unavailable", "product catalog is not available"},
+	{tax.ErrUnavailable, http.StatusServiceUnavailable, "tax-unavailable", "Tax Service Unavailable", "tax calculation is not available"},
+	{order.ErrUnavailable, http.StatusServiceUnavailable, "order-unavailable", "Order Service Unavailable", "order processing is not available"},
+	{customer.ErrUnavailable, http.StatusServiceUnavailable, "customer-unavailable", "Customer Service Unavailable", "customer service is not available"},
+	{agent.ErrUnavailable, http.StatusServiceUnavailable, "agent-unavailable", "Agent Service Unavailable", "agent assignments are not available"},
+}
+
+// internalProblem answers errors which are not registered, their text is only logged.
+var internalProblem = problemType{
+	status: http.StatusInternalServerError,
+	title:  http.StatusText(http.StatusInternalServerError),
+}
+
+func findProblemType(err error) (problemType, bool) {
+	for _, problemType := range problemTypes {
+		if errors.Is(err, problemType.err) {
+			return problemType, true
+		}
+	}
+
+	return internalProblem, false
+}
+
+func respondError(w http.ResponseWriter, r *http.Request, err error) error {
+	var (
+		validationError *types.ValidationError
+		errs            []problemError
+	)
+	if errors.As(err, &validationError) {
+		errs = make([]problemError, 0, len(validationError.Fields))
+		for _, field := range validationError.Fields {
+			errs = append(errs, problemError{
+				Location: "body",
+				Field:    field.Field,
+				Message:  field.Message,
+			})
+		}
+	}
+
+	return respondProblem(w, r, err, errs)
+}
+
+// respondProblem writes the problem details of the error, errs lists the invalid fields.
+func respondProblem(w http.ResponseWriter, r *http.Request, err error, errs []problemError) error {
+	problemType, found := findProblemType(err)
+	if !found || problemType.status >= http.StatusInternalServerError {
+		log.Printf("Request %s %s failed: %v", r.Method, logPath(r), err)
+	}
+
+	body := problem{
+		Type:      "about:blank",
+		Title:     problemType.title,
+		Status:    problemType.status,
+		Detail:    problemType.detail,
+		Instance:  r.URL.Path,
+		RequestID: middleware.GetReqID(r.Context()),
+		Errors:    errs,
+	}
+	if problemType.name != "" {
+		body.Type = problemTypeURIPrefix + problemType.name
+	}
+
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problemType.status)
+
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		return fmt.Errorf("encode: %w", err)
+	}
+	return nil
+}
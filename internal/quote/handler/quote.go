@@ -9,43 +9,18 @@ import (
 
 	"github.com/google/uuid"
 
+	v1 "app/internal/quote/handler/v1"
 	"app/internal/quote/types"
 )
 
 const (
-	URLCustomerIDParameter string = "customerID"
-	URLProductIDParameter  string = "productID"
+	URLCustomerIDParameter  string = "customerID"
+	URLProductIDParameter   string = "productID"
+	URLAgentIDParameter     string = "agentID"
+	URLQuoteNumberParameter string = "quoteNumber"
 )
 
 type (
-	quoteResponse struct {
-		ID          uuid.UUID         `json:"id"`
-		Address     addressResponse   `json:"address"`
-		Payment     paymentResponse   `json:"payment"`
-		Products    []productResponse `json:"products"`
-		Amount      float64           `json:"amount"`
-		TaxAmount   float64           `json:"tax_amount"`
-		TotalAmount float64           `json:"total_amount"`
-	}
-
-	addressResponse struct {
-		Address string `json:"address"`
-		City    string `json:"city"`
-		Country string `json:"country"`
-	}
-
-	paymentResponse struct {
-		PaymentMethod string `json:"payment_method"`
-	}
-
-	productResponse struct {
-		ID          uuid.UUID `json:"product_id"`
-		Quantity    int       `json:"qty"`
-		Amount      float64   `json:"amount"`
-		TaxAmount   float64   `json:"tax_amount"`
-		TotalAmount float64   `json:"total_amount"`
-	}
-
 	addressRequest struct {
 		Address string `json:"address"`
 		City    string `json:"city"`
@@ -64,14 +39,48 @@ type (
 	productUpdateRequest struct {
 		Quantity int `json:"qty"`
 	}
+
+	productDiscountRequest struct {
+		Discount float64 `json:"discount_percent"`
+	}
+
+	productPriceRequest struct {
+		Price float64 `json:"price"`
+	}
+
+	productOperationsRequest struct {
+		Operations []productOperationRequest `json:"operations"`
+	}
+
+	productOperationRequest struct {
+		Op        string `json:"op"`
+		ProductID string `json:"product_id"`
+		Quantity  int    `json:"qty"`
+	}
 )
 
 type (
 	quoteService interface {
+		Accept(ctx context.Context, customerUUID uuid.UUID, acceptance types.Acceptance) (*types.Quote, error)
+		AcceptOffer(ctx context.Context, customerUUID uuid.UUID, number int) error
 		AddProduct(ctx context.Context, customerUUID uuid.UUID, product *types.ProductAdd) error
+		ApplyProductOperations(ctx context.Context, customerUUID uuid.UUID, operations []types.ProductOperation) ([]types.ProductOperationResult, error)
+		ApplyDiscount(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, discount *types.ProductDiscount) error
+		ApprovePriceOverride(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID) error
 		UpdateProduct(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, product *types.ProductUpdate) error
+		ListDrafts(ctx context.Context, customerUUID uuid.UUID) ([]*types.Quote, error)
+		CreateDraft(ctx context.Context, customerUUID uuid.UUID, name string) (*types.Quote, error)
+		RenameDraft(ctx context.Context, customerUUID uuid.UUID, name string) error
+		ActivateDraft(ctx context.Context, customerUUID uuid.UUID) error
+		ImportProducts(ctx context.Context, customerUUID uuid.UUID, lines []types.ImportLine) ([]types.ProductOperationResult, error)
 		LoadDraftByCustomer(ctx context.Context, customerUUID uuid.UUID) (*types.Quote, error)
+		LoadByID(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) (*types.Quote, error)
+		LoadByNumber(ctx context.Context, customerUUID uuid.UUID, number string) (*types.Quote, error)
+		OverridePrice(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID, override *types.ProductPriceOverride) error
 		ProcessByCustomerID(ctx context.Context, customerUUID uuid.UUID) error
+		ProposeOffer(ctx context.Context, customerUUID uuid.UUID, create types.OfferCreate) error
+		Reorder(ctx context.Context, customerUUID uuid.UUID, reorder types.Reorder) (*types.ReorderResult, error)
+		RejectPriceOverride(ctx context.Context, customerUUID uuid.UUID, productUUID uuid.UUID) error
 		RemoveProduct(ctx context.Context, customerUUID uuid.UUID, productID uuid.UUID) error
 		SaveAddress(ctx context.Context, customerUUID uuid.UUID, address *types.Address) error
 		SavePayment(ctx context.Context, customerUUID uuid.UUID, payment *types.Payment) error
@@ -95,7 +104,30 @@ func (q *APIHandler) GetQuote() BaseHandler {
 			return fmt.Errorf("APIHandler::GetQuote : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+// GetQuoteByNumber returns any quote of the customer by its quote number, e.g. one read out over the phone.
+func (q *APIHandler) GetQuoteByNumber() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::GetQuoteByNumber : %w", err)
+		}
+
+		number, err := getParam(r, URLQuoteNumberParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::GetQuoteByNumber : %w", err)
+		}
+
+		quote, err := q.quoteService.LoadByNumber(r.Context(), customerID, number)
+		if err != nil {
+			return fmt.Errorf("APIHandler::GetQuoteByNumber : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewQuoteResponse(quote), http.StatusOK)
 	}
 }
 
@@ -126,7 +158,7 @@ func (q *APIHandler) UpdateAddress() BaseHandler {
 			return fmt.Errorf("APIHandler::UpdateAddress : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		return q.respondQuote(w, r, customerID)
 	}
 }
 
@@ -155,7 +187,7 @@ func (q *APIHandler) UpdatePayment() BaseHandler {
 			return fmt.Errorf("APIHandler::UpdatePayment : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		return q.respondQuote(w, r, customerID)
 	}
 }
 
@@ -170,7 +202,7 @@ func (q *APIHandler) Process() BaseHandler {
 			return fmt.Errorf("APIHandler::Process : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		return q.respondQuote(w, r, customerID)
 	}
 }
 
@@ -192,12 +224,20 @@ func (q *APIHandler) AddProduct() BaseHandler {
 			return fmt.Errorf("APIHandler::AddProduct : %w: %w", errBodyRead, err)
 		}
 
-		err = q.quoteService.AddProduct(r.Context(), customerID, &types.ProductAdd{})
+		productID, err := uuid.Parse(request.ProductID)
+		if err != nil {
+			return fmt.Errorf("APIHandler::AddProduct : %w: %w", errInvalidParameter, err)
+		}
+
+		err = q.quoteService.AddProduct(r.Context(), customerID, &types.ProductAdd{
+			ProductID: productID,
+			Quantity:  request.Quantity,
+		})
 		if err != nil {
 			return fmt.Errorf("APIHandler::AddProduct : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		return q.respondQuote(w, r, customerID)
 	}
 }
 
@@ -228,10 +268,166 @@ func (q *APIHandler) UpdateProduct() BaseHandler {
 			Quantity: request.Quantity,
 		})
 		if err != nil {
-			return fmt.Errorf("APIHandler::DeleteProduct : %w", err)
+			return fmt.Errorf("APIHandler::UpdateProduct : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+func (q *APIHandler) ApplyDiscount() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyDiscount : %w", err)
+		}
+
+		productID, err := getParamUUID(r, URLProductIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyDiscount : %w", err)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyDiscount : %w: %w", errBodyRead, err)
+		}
+
+		var request productDiscountRequest
+		err = json.Unmarshal(body, &request)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyDiscount : %w: %w", errBodyRead, err)
+		}
+
+		err = q.quoteService.ApplyDiscount(r.Context(), customerID, productID, &types.ProductDiscount{
+			Discount: request.Discount,
+		})
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyDiscount : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+func (q *APIHandler) OverridePrice() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::OverridePrice : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		productID, err := getParamUUID(r, URLProductIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::OverridePrice : %w", err)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("APIHandler::OverridePrice : %w: %w", errBodyRead, err)
+		}
+
+		var request productPriceRequest
+		err = json.Unmarshal(body, &request)
+		if err != nil {
+			return fmt.Errorf("APIHandler::OverridePrice : %w: %w", errBodyRead, err)
+		}
+
+		err = q.quoteService.OverridePrice(r.Context(), customerID, productID, &types.ProductPriceOverride{
+			Price: request.Price,
+		})
+		if err != nil {
+			return fmt.Errorf("APIHandler::OverridePrice : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+func (q *APIHandler) ApprovePriceOverride() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApprovePriceOverride : %w", err)
+		}
+
+		productID, err := getParamUUID(r, URLProductIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApprovePriceOverride : %w", err)
+		}
+
+		if err := q.quoteService.ApprovePriceOverride(r.Context(), customerID, productID); err != nil {
+			return fmt.Errorf("APIHandler::ApprovePriceOverride : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+func (q *APIHandler) RejectPriceOverride() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::RejectPriceOverride : %w", err)
+		}
+
+		productID, err := getParamUUID(r, URLProductIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::RejectPriceOverride : %w", err)
+		}
+
+		if err := q.quoteService.RejectPriceOverride(r.Context(), customerID, productID); err != nil {
+			return fmt.Errorf("APIHandler::RejectPriceOverride : %w", err)
+		}
+
+		return q.respondQuote(w, r, customerID)
+	}
+}
+
+// ApplyProductOperations applies a list of add, update and remove operations to the quote lines at once.
+func (q *APIHandler) ApplyProductOperations() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyProductOperations : %w", err)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyProductOperations : %w: %w", errBodyRead, err)
+		}
+
+		var request productOperationsRequest
+		err = json.Unmarshal(body, &request)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyProductOperations : %w: %w", errBodyRead, err)
+		}
+
+		operations := make([]types.ProductOperation, 0, len(request.Operations))
+		for _, operation := range request.Operations {
+			productID, err := uuid.Parse(operation.ProductID)
+			if err != nil {
+				return fmt.Errorf("APIHandler::ApplyProductOperations : %w: %w", errInvalidParameter, err)
+			}
+
+			operations = append(operations, types.ProductOperation{
+				Type:      types.ProductOperationType(operation.Op),
+				ProductID: productID,
+				Quantity:  operation.Quantity,
+			})
+		}
+
+		results, err := q.quoteService.ApplyProductOperations(r.Context(), customerID, operations)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyProductOperations : %w", err)
+		}
+
+		quote, err := q.quoteService.LoadDraftByCustomer(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("APIHandler::ApplyProductOperations : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewProductOperationsResponse(results, quote), http.StatusOK)
 	}
 }
 
@@ -251,19 +447,31 @@ func (q *APIHandler) DeleteProduct() BaseHandler {
 			return fmt.Errorf("APIHandler::DeleteProduct : %w", err)
 		}
 
-		return q.respondQuote(r.Context(), w, customerID)
+		return q.respondQuote(w, r, customerID)
 	}
 }
 
-func (q *APIHandler) respondQuote(ctx context.Context, w http.ResponseWriter, customerID uuid.UUID) error {
-	quote, err := q.quoteService.LoadDraftByCustomer(ctx, customerID)
+// respondQuote writes the draft with its entity tag, a GET request already having the tag gets 304.
+func (q *APIHandler) respondQuote(w http.ResponseWriter, r *http.Request, customerID uuid.UUID) error {
+	quote, err := q.loadQuote(r.Context(), customerID)
 	if err != nil {
 		return fmt.Errorf("APIHandler::respondQuote : %w", err)
 	}
 
-	return respond(
-		w,
-		quote, // need to change it here...
-		http.StatusOK,
-	)
+	w.Header().Set("ETag", quote.ETag())
+	if r.Method == http.MethodGet && quote.MatchesETag(parseETags(r.Header.Get("If-None-Match"), true)) {
+		return respond(w, nil, http.StatusNotModified)
+	}
+
+	return respond(w, v1.NewQuoteResponse(quote), http.StatusOK)
+}
+
+// loadQuote loads the draft the request changed. A draft scoped by DraftScopeMiddleware is loaded in any status,
+// so processing it responds with the processed quote instead of failing as it is no draft anymore.
+func (q *APIHandler) loadQuote(ctx context.Context, customerID uuid.UUID) (*types.Quote, error) {
+	if quoteID, ok := types.DraftIDFromContext(ctx); ok {
+		return q.quoteService.LoadByID(ctx, customerID, quoteID)
+	}
+
+	return q.quoteService.LoadDraftByCustomer(ctx, customerID)
 }
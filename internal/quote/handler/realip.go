@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// ParseTrustedProxies reads the addresses of the trusted proxies, each one an IP address or a CIDR range.
+func ParseTrustedProxies(values []string) ([]netip.Prefix, error) {
+	proxies := make([]netip.Prefix, 0, len(values))
+	for _, value := range values {
+		if value == "" {
+			continue
+		}
+
+		if strings.Contains(value, "/") {
+			prefix, err := netip.ParsePrefix(value)
+			if err != nil {
+				return nil, fmt.Errorf("trusted proxy %q: %w", value, err)
+			}
+
+			proxies = append(proxies, prefix.Masked())
+			continue
+		}
+
+		addr, err := netip.ParseAddr(value)
+		if err != nil {
+			return nil, fmt.Errorf("trusted proxy %q: %w", value, err)
+		}
+
+		proxies = append(proxies, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	return proxies, nil
+}
+
+// RealIPMiddleware replaces the remote address of the request by the client address of the X-Forwarded-For or
+// X-Real-IP header, but only for requests coming from a trusted proxy. A client connecting directly can not choose
+// the address recorded for it, e.g. in the acceptance of a quote.
+func RealIPMiddleware(trustedProxies []netip.Prefix) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isTrustedProxy(trustedProxies, remoteIP(r)) {
+				if ip := forwardedIP(trustedProxies, r); ip != "" {
+					r.RemoteAddr = ip
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// forwardedIP returns the nearest address of the X-Forwarded-For chain which is not a trusted proxy, the addresses
+// in front of it are set by the client itself.
+func forwardedIP(trustedProxies []netip.Prefix, r *http.Request) string {
+	if header := r.Header.Get("X-Forwarded-For"); header != "" {
+		chain := strings.Split(header, ",")
+		for i := len(chain) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(chain[i])
+			if _, err := netip.ParseAddr(ip); err != nil {
+				return ""
+			}
+
+			if i == 0 || !isTrustedProxy(trustedProxies, ip) {
+				return ip
+			}
+		}
+	}
+
+	ip := strings.TrimSpace(r.Header.Get("X-Real-IP"))
+	if _, err := netip.ParseAddr(ip); err != nil {
+		return ""
+	}
+
+	return ip
+}
+
+func isTrustedProxy(trustedProxies []netip.Prefix, ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+
+	addr = addr.Unmap()
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remoteIP returns the address of the client without the port, RealIPMiddleware has already replaced it by the
+// forwarded address when the request comes from a trusted proxy.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+type reorderRequest struct {
+	SourceID string `json:"source_id"`
+	Address  bool   `json:"address"`
+	Payment  bool   `json:"payment"`
+}
+
+// Reorder copies the lines of a past quote into the draft and reports the lines which could not be copied.
+func (q *APIHandler) Reorder() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("APIHandler::Reorder : %w", err)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("APIHandler::Reorder : %w: %w", errBodyRead, err)
+		}
+
+		var request reorderRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fmt.Errorf("APIHandler::Reorder : %w: %w", errBodyRead, err)
+		}
+
+		sourceID, err := uuid.Parse(request.SourceID)
+		if err != nil {
+			return fmt.Errorf("APIHandler::Reorder : %w: %w", errInvalidParameter, err)
+		}
+
+		result, err := q.quoteService.Reorder(r.Context(), customerID, types.Reorder{
+			SourceID:       sourceID,
+			IncludeAddress: request.Address,
+			IncludePayment: request.Payment,
+		})
+		if err != nil {
+			return fmt.Errorf("APIHandler::Reorder : %w", err)
+		}
+
+		quote, err := q.quoteService.LoadDraftByCustomer(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("APIHandler::Reorder : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewReorderResponse(result, quote), http.StatusOK)
+	}
+}
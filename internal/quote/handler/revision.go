@@ -0,0 +1,180 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+const (
+	URLRevisionParameter string = "revision"
+	QueryFromParameter   string = "from"
+	QueryToParameter     string = "to"
+)
+
+type (
+	revisionResponse struct {
+		Number    int               `json:"number"`
+		CreatedAt time.Time         `json:"created_at"`
+		CreatedBy *v1.ActorResponse `json:"created_by"`
+		Quote     *v1.QuoteResponse `json:"quote,omitempty"`
+	}
+
+	quoteDiffResponse struct {
+		QuoteID     uuid.UUID          `json:"quote_id"`
+		From        int                `json:"from"`
+		To          int                `json:"to"`
+		Lines       []lineDiffResponse `json:"lines"`
+		Amount      amountDiffResponse `json:"amount"`
+		TaxAmount   amountDiffResponse `json:"tax_amount"`
+		TotalAmount amountDiffResponse `json:"total_amount"`
+	}
+
+	lineDiffResponse struct {
+		ProductID   uuid.UUID          `json:"product_id"`
+		Change      string             `json:"change"`
+		Quantity    quantityDiff       `json:"qty"`
+		Amount      amountDiffResponse `json:"amount"`
+		TotalAmount amountDiffResponse `json:"total_amount"`
+	}
+
+	quantityDiff struct {
+		From int `json:"from"`
+		To   int `json:"to"`
+	}
+
+	amountDiffResponse struct {
+		From float64 `json:"from"`
+		To   float64 `json:"to"`
+	}
+)
+
+type (
+	revisionService interface {
+		ListRevisions(ctx context.Context, customerUUID uuid.UUID) ([]types.QuoteRevision, error)
+		LoadRevision(ctx context.Context, customerUUID uuid.UUID, number int) (*types.QuoteRevision, error)
+		DiffRevisions(ctx context.Context, customerUUID uuid.UUID, from int, to int) (*types.QuoteDiff, error)
+	}
+
+	RevisionHandler struct {
+		revisionService revisionService
+	}
+)
+
+func NewRevisionHandler(revisionService revisionService) *RevisionHandler {
+	return &RevisionHandler{
+		revisionService: revisionService,
+	}
+}
+
+func (h *RevisionHandler) ListRevisions() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::ListRevisions : %w", err)
+		}
+
+		revisions, err := h.revisionService.ListRevisions(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::ListRevisions : %w", err)
+		}
+
+		response := make([]revisionResponse, 0, len(revisions))
+		for i := range revisions {
+			response = append(response, newRevisionResponse(&revisions[i], false))
+		}
+
+		return respond(w, response, http.StatusOK)
+	}
+}
+
+func (h *RevisionHandler) GetRevision() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::GetRevision : %w", err)
+		}
+
+		number, err := getParamInt(r, URLRevisionParameter)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::GetRevision : %w", err)
+		}
+
+		revision, err := h.revisionService.LoadRevision(r.Context(), customerID, number)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::GetRevision : %w", err)
+		}
+
+		return respond(w, newRevisionResponse(revision, true), http.StatusOK)
+	}
+}
+
+func (h *RevisionHandler) DiffRevisions() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::DiffRevisions : %w", err)
+		}
+
+		from, err := getQueryInt(r, QueryFromParameter)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::DiffRevisions : %w", err)
+		}
+
+		to, err := getQueryInt(r, QueryToParameter)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::DiffRevisions : %w", err)
+		}
+
+		diff, err := h.revisionService.DiffRevisions(r.Context(), customerID, from, to)
+		if err != nil {
+			return fmt.Errorf("RevisionHandler::DiffRevisions : %w", err)
+		}
+
+		return respond(w, newQuoteDiffResponse(diff), http.StatusOK)
+	}
+}
+
+func newRevisionResponse(revision *types.QuoteRevision, withQuote bool) revisionResponse {
+	response := revisionResponse{
+		Number:    revision.Number,
+		CreatedAt: revision.CreatedAt,
+		CreatedBy: v1.NewActorResponse(revision.CreatedBy),
+	}
+	if withQuote {
+		quote := v1.NewQuoteResponse(&revision.Quote)
+		response.Quote = &quote
+	}
+
+	return response
+}
+
+func newQuoteDiffResponse(diff *types.QuoteDiff) quoteDiffResponse {
+	response := quoteDiffResponse{
+		QuoteID:     diff.QuoteID,
+		From:        diff.From,
+		To:          diff.To,
+		Lines:       make([]lineDiffResponse, 0, len(diff.Lines)),
+		Amount:      amountDiffResponse(diff.Amount),
+		TaxAmount:   amountDiffResponse(diff.TaxAmount),
+		TotalAmount: amountDiffResponse(diff.TotalAmount),
+	}
+
+	for _, line := range diff.Lines {
+		response.Lines = append(response.Lines, lineDiffResponse{
+			ProductID:   line.ProductID,
+			Change:      string(line.Change),
+			Quantity:    quantityDiff(line.Quantity),
+			Amount:      amountDiffResponse(line.Amount),
+			TotalAmount: amountDiffResponse(line.TotalAmount),
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+const (
+	URLShareIDParameter    string = "shareID"
+	URLShareTokenParameter string = "token"
+)
+
+type shareRequest struct {
+	Revision  int `json:"revision"`
+	ExpiresIn int `json:"expires_in"`
+}
+
+type (
+	shareService interface {
+		CreateShare(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, create types.ShareCreate) (*types.Share, string, error)
+		ListShares(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID) ([]*types.Share, error)
+		RevokeShare(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, shareUUID uuid.UUID) error
+		ListShareAccesses(ctx context.Context, customerUUID uuid.UUID, quoteUUID uuid.UUID, shareUUID uuid.UUID) ([]types.ShareAccess, error)
+		ResolveShare(ctx context.Context, token string, access types.ShareAccess) (*types.Share, *types.QuoteRevision, error)
+	}
+
+	ShareHandler struct {
+		shareService shareService
+	}
+)
+
+func NewShareHandler(shareService shareService) *ShareHandler {
+	return &ShareHandler{
+		shareService: shareService,
+	}
+}
+
+// CreateShare issues a read-only link to a revision of the quote, the token is only returned here.
+func (h *ShareHandler) CreateShare() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, quoteID, err := getShareQuoteParams(r)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::CreateShare : %w", err)
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::CreateShare : %w: %w", errBodyRead, err)
+		}
+
+		var request shareRequest
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fmt.Errorf("ShareHandler::CreateShare : %w: %w", errBodyRead, err)
+		}
+
+		share, token, err := h.shareService.CreateShare(r.Context(), customerID, quoteID, types.ShareCreate{
+			Revision: request.Revision,
+			TTL:      time.Duration(request.ExpiresIn) * time.Second,
+		})
+		if err != nil {
+			return fmt.Errorf("ShareHandler::CreateShare : %w", err)
+		}
+
+		return respond(w, v1.NewCreatedShareResponse(share, token, time.Now()), http.StatusCreated)
+	}
+}
+
+func (h *ShareHandler) ListShares() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, quoteID, err := getShareQuoteParams(r)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::ListShares : %w", err)
+		}
+
+		shares, err := h.shareService.ListShares(r.Context(), customerID, quoteID)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::ListShares : %w", err)
+		}
+
+		return respond(w, v1.NewShareListResponse(shares, time.Now()), http.StatusOK)
+	}
+}
+
+func (h *ShareHandler) RevokeShare() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, quoteID, err := getShareQuoteParams(r)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::RevokeShare : %w", err)
+		}
+
+		shareID, err := getParamUUID(r, URLShareIDParameter)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::RevokeShare : %w", err)
+		}
+
+		if err := h.shareService.RevokeShare(r.Context(), customerID, quoteID, shareID); err != nil {
+			return fmt.Errorf("ShareHandler::RevokeShare : %w", err)
+		}
+
+		return respond(w, nil, http.StatusNoContent)
+	}
+}
+
+func (h *ShareHandler) ListShareAccesses() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, quoteID, err := getShareQuoteParams(r)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::ListShareAccesses : %w", err)
+		}
+
+		shareID, err := getParamUUID(r, URLShareIDParameter)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::ListShareAccesses : %w", err)
+		}
+
+		accesses, err := h.shareService.ListShareAccesses(r.Context(), customerID, quoteID, shareID)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::ListShareAccesses : %w", err)
+		}
+
+		return respond(w, v1.NewShareAccessListResponse(accesses), http.StatusOK)
+	}
+}
+
+// GetSharedQuote resolves a share link without authentication, the signed token is the only credential.
+// The shared revision is not cached, so a revoked link stops working at once.
+func (h *ShareHandler) GetSharedQuote() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		token, err := getParam(r, URLShareTokenParameter)
+		if err != nil {
+			return fmt.Errorf("ShareHandler::GetSharedQuote : %w", err)
+		}
+
+		share, revision, err := h.shareService.ResolveShare(r.Context(), token, types.ShareAccess{
+			IP:        remoteIP(r),
+			UserAgent: r.UserAgent(),
+		})
+		if err != nil {
+			return fmt.Errorf("ShareHandler::GetSharedQuote : %w", err)
+		}
+
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Referrer-Policy", "no-referrer")
+		return respond(w, v1.NewSharedRevisionResponse(share, revision), http.StatusOK)
+	}
+}
+
+func getShareQuoteParams(r *http.Request) (uuid.UUID, uuid.UUID, error) {
+	customerID, err := getParamUUID(r, URLCustomerIDParameter)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	quoteID, err := getParamUUID(r, URLQuoteIDParameter)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+
+	return customerID, quoteID, nil
+}
@@ -0,0 +1,197 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+const URLTemplateIDParameter string = "templateID"
+
+type (
+	templateRequest struct {
+		Name        string                `json:"name"`
+		Description string                `json:"description"`
+		Lines       []templateLineRequest `json:"lines"`
+	}
+
+	templateLineRequest struct {
+		ProductID string `json:"product_id"`
+		Quantity  int    `json:"qty"`
+	}
+)
+
+type (
+	templateService interface {
+		ListTemplates(ctx context.Context) ([]*types.Template, error)
+		LoadTemplate(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error)
+		CreateTemplate(ctx context.Context, template *types.Template) (*types.Template, error)
+		UpdateTemplate(ctx context.Context, templateUUID uuid.UUID, template *types.Template) (*types.Template, error)
+		DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error
+	}
+
+	// templateQuoteService applies the loaded templates to the drafts.
+	templateQuoteService interface {
+		ApplyTemplate(ctx context.Context, customerUUID uuid.UUID, template *types.Template) ([]types.ProductOperationResult, error)
+		LoadDraftByCustomer(ctx context.Context, customerUUID uuid.UUID) (*types.Quote, error)
+	}
+
+	TemplateHandler struct {
+		templateService templateService
+		quoteService    templateQuoteService
+	}
+)
+
+func NewTemplateHandler(templateService templateService, quoteService templateQuoteService) *TemplateHandler {
+	return &TemplateHandler{
+		templateService: templateService,
+		quoteService:    quoteService,
+	}
+}
+
+func (h *TemplateHandler) ListTemplates() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		templates, err := h.templateService.ListTemplates(r.Context())
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::ListTemplates : %w", err)
+		}
+
+		return respond(w, v1.NewTemplateListResponse(templates), http.StatusOK)
+	}
+}
+
+func (h *TemplateHandler) GetTemplate() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		templateID, err := getParamUUID(r, URLTemplateIDParameter)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::GetTemplate : %w", err)
+		}
+
+		template, err := h.templateService.LoadTemplate(r.Context(), templateID)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::GetTemplate : %w", err)
+		}
+
+		return respond(w, v1.NewTemplateResponse(template), http.StatusOK)
+	}
+}
+
+func (h *TemplateHandler) CreateTemplate() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		request, err := readTemplateRequest(r)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::CreateTemplate : %w", err)
+		}
+
+		template, err := h.templateService.CreateTemplate(r.Context(), request)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::CreateTemplate : %w", err)
+		}
+
+		return respond(w, v1.NewTemplateResponse(template), http.StatusCreated)
+	}
+}
+
+func (h *TemplateHandler) UpdateTemplate() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		templateID, err := getParamUUID(r, URLTemplateIDParameter)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::UpdateTemplate : %w", err)
+		}
+
+		request, err := readTemplateRequest(r)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::UpdateTemplate : %w", err)
+		}
+
+		template, err := h.templateService.UpdateTemplate(r.Context(), templateID, request)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::UpdateTemplate : %w", err)
+		}
+
+		return respond(w, v1.NewTemplateResponse(template), http.StatusOK)
+	}
+}
+
+func (h *TemplateHandler) DeleteTemplate() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		templateID, err := getParamUUID(r, URLTemplateIDParameter)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::DeleteTemplate : %w", err)
+		}
+
+		if err := h.templateService.DeleteTemplate(r.Context(), templateID); err != nil {
+			return fmt.Errorf("TemplateHandler::DeleteTemplate : %w", err)
+		}
+
+		return respond(w, nil, http.StatusNoContent)
+	}
+}
+
+// ApplyTemplate merges the lines of a quote template into the draft.
+func (h *TemplateHandler) ApplyTemplate() BaseHandler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		customerID, err := getParamUUID(r, URLCustomerIDParameter)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::ApplyTemplate : %w", err)
+		}
+
+		templateID, err := getParamUUID(r, URLTemplateIDParameter)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::ApplyTemplate : %w", err)
+		}
+
+		template, err := h.templateService.LoadTemplate(r.Context(), templateID)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::ApplyTemplate : %w", err)
+		}
+
+		results, err := h.quoteService.ApplyTemplate(r.Context(), customerID, template)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::ApplyTemplate : %w", err)
+		}
+
+		quote, err := h.quoteService.LoadDraftByCustomer(r.Context(), customerID)
+		if err != nil {
+			return fmt.Errorf("TemplateHandler::ApplyTemplate : %w", err)
+		}
+
+		w.Header().Set("ETag", quote.ETag())
+		return respond(w, v1.NewProductOperationsResponse(results, quote), http.StatusOK)
+	}
+}
+
+func readTemplateRequest(r *http.Request) (*types.Template, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errBodyRead, err)
+	}
+
+	var request templateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return nil, fmt.Errorf("%w: %w", errBodyRead, err)
+	}
+
+	template := &types.Template{
+		Name:        request.Name,
+		Description: request.Description,
+		Lines:       make([]types.TemplateLine, 0, len(request.Lines)),
+	}
+	for _, line := range request.Lines {
+		productID, err := uuid.Parse(line.ProductID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", errInvalidParameter, err)
+		}
+
+		template.Lines = append(template.Lines, types.TemplateLine{ProductID: productID, Quantity: line.Quantity})
+	}
+
+	return template, nil
+}
@@ -0,0 +1,37 @@
+package v1
+
+import (
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+type (
+	ProductImportResponse struct {
+		Results []ProductImportResultResponse `json:"results"`
+		Quote   QuoteResponse                 `json:"quote"`
+	}
+
+	ProductImportResultResponse struct {
+		Row       int       `json:"row"`
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+	}
+)
+
+// NewProductImportResponse maps the results to the rows of the imported lines they are indexed by.
+func NewProductImportResponse(results []types.ProductOperationResult, lines []types.ImportLine, quote *types.Quote) ProductImportResponse {
+	response := ProductImportResponse{
+		Results: make([]ProductImportResultResponse, 0, len(results)),
+		Quote:   NewQuoteResponse(quote),
+	}
+	for _, result := range results {
+		response.Results = append(response.Results, ProductImportResultResponse{
+			Row:       lines[result.Index].Row,
+			ProductID: result.ProductID,
+			Quantity:  result.Quantity,
+		})
+	}
+
+	return response
+}
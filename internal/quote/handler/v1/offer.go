@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+type (
+	// NegotiationResponse is the offer thread of a quote with the quote priced by the agreed offer.
+	NegotiationResponse struct {
+		AgreedOffer *int            `json:"agreed_offer"`
+		Offers      []OfferResponse `json:"offers"`
+		Quote       QuoteResponse   `json:"quote"`
+	}
+
+	OfferResponse struct {
+		Number     int                 `json:"number"`
+		Side       string              `json:"side"`
+		Status     string              `json:"status"`
+		Revision   int                 `json:"revision"`
+		Note       string              `json:"note"`
+		Lines      []OfferLineResponse `json:"lines"`
+		ProposedBy *ActorResponse      `json:"proposed_by"`
+		ProposedAt time.Time           `json:"proposed_at"`
+		DecidedBy  *ActorResponse      `json:"decided_by"`
+		DecidedAt  *time.Time          `json:"decided_at"`
+	}
+
+	OfferLineResponse struct {
+		ProductID      uuid.UUID `json:"product_id"`
+		Price          float64   `json:"price"`
+		Discount       float64   `json:"discount_percent"`
+		ApprovalStatus *string   `json:"approval_status"`
+	}
+)
+
+// NewNegotiationResponse maps the offers of the quote oldest first, an open offer has a null decided_at and a line
+// which needs no approval a null approval_status.
+func NewNegotiationResponse(quote *types.Quote) NegotiationResponse {
+	response := NegotiationResponse{
+		Offers: make([]OfferResponse, 0, len(quote.Offers)),
+		Quote:  NewQuoteResponse(quote),
+	}
+
+	if agreed := quote.AgreedOffer(); agreed != nil {
+		response.AgreedOffer = &agreed.Number
+	}
+
+	for _, offer := range quote.Offers {
+		response.Offers = append(response.Offers, newOfferResponse(offer))
+	}
+
+	return response
+}
+
+func newOfferResponse(offer types.Offer) OfferResponse {
+	response := OfferResponse{
+		Number:     offer.Number,
+		Side:       string(offer.Side),
+		Status:     string(offer.Status),
+		Revision:   offer.Revision,
+		Note:       offer.Note,
+		Lines:      make([]OfferLineResponse, 0, len(offer.Lines)),
+		ProposedBy: NewActorResponse(offer.ProposedBy),
+		ProposedAt: offer.ProposedAt,
+		DecidedBy:  NewActorResponse(offer.DecidedBy),
+	}
+
+	if !offer.DecidedAt.IsZero() {
+		response.DecidedAt = &offer.DecidedAt
+	}
+
+	for _, line := range offer.Lines {
+		lineResponse := OfferLineResponse{
+			ProductID: line.ProductID,
+			Price:     line.Price,
+			Discount:  line.Discount,
+		}
+		if line.Approval != "" {
+			status := string(line.Approval)
+			lineResponse.ApprovalStatus = &status
+		}
+
+		response.Lines = append(response.Lines, lineResponse)
+	}
+
+	return response
+}
@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+type (
+	ProductOperationsResponse struct {
+		Results []ProductOperationResultResponse `json:"results"`
+		Quote   QuoteResponse                    `json:"quote"`
+	}
+
+	ProductOperationResultResponse struct {
+		Index     int       `json:"index"`
+		Op        string    `json:"op"`
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+	}
+)
+
+func NewProductOperationsResponse(results []types.ProductOperationResult, quote *types.Quote) ProductOperationsResponse {
+	response := ProductOperationsResponse{
+		Results: make([]ProductOperationResultResponse, 0, len(results)),
+		Quote:   NewQuoteResponse(quote),
+	}
+	for _, result := range results {
+		response.Results = append(response.Results, ProductOperationResultResponse{
+			Index:     result.Index,
+			Op:        string(result.Type),
+			ProductID: result.ProductID,
+			Quantity:  result.Quantity,
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,203 @@
+// Package v1 maps quote domain types to the version 1 response contract described in Openapi.yaml.
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+type (
+	QuoteResponse struct {
+		ID          uuid.UUID           `json:"id"`
+		Number      *string             `json:"number"`
+		Name        *string             `json:"name"`
+		Status      string              `json:"status"`
+		Revision    int                 `json:"revision"`
+		UpdatedAt   time.Time           `json:"updated_at"`
+		UpdatedBy   *ActorResponse      `json:"updated_by"`
+		Address     *AddressResponse    `json:"address"`
+		Payment     *PaymentResponse    `json:"payment"`
+		Products    []ProductResponse   `json:"products"`
+		Amount      float64             `json:"amount"`
+		TaxAmount   float64             `json:"tax_amount"`
+		TotalAmount float64             `json:"total_amount"`
+		Acceptance  *AcceptanceResponse `json:"acceptance"`
+	}
+
+	// AcceptanceResponse is the customer's acceptance of the terms, RevisionHash is the SHA-256 of the accepted
+	// quote content.
+	AcceptanceResponse struct {
+		Name         string    `json:"name"`
+		Email        string    `json:"email"`
+		AcceptedAt   time.Time `json:"accepted_at"`
+		IP           string    `json:"ip"`
+		UserAgent    string    `json:"user_agent"`
+		Revision     int       `json:"revision"`
+		RevisionHash string    `json:"revision_hash"`
+		TermsVersion string    `json:"terms_version"`
+	}
+
+	// DraftListResponse lists the drafts of a customer, ActiveID is the draft the single quote routes change.
+	DraftListResponse struct {
+		ActiveID *uuid.UUID      `json:"active_id"`
+		Drafts   []QuoteResponse `json:"drafts"`
+	}
+
+	QuoteListResponse struct {
+		Quotes     []QuoteResponse `json:"quotes"`
+		NextCursor *string         `json:"next_cursor"`
+	}
+
+	AddressResponse struct {
+		Address string `json:"address"`
+		City    string `json:"city"`
+		Country string `json:"country"`
+	}
+
+	PaymentResponse struct {
+		PaymentMethod string `json:"payment_method"`
+	}
+
+	ProductResponse struct {
+		ID            uuid.UUID              `json:"product_id"`
+		Quantity      int                    `json:"qty"`
+		Discount      float64                `json:"discount_percent"`
+		PriceOverride *PriceOverrideResponse `json:"price_override"`
+		Amount        float64                `json:"amount"`
+		TaxAmount     float64                `json:"tax_amount"`
+		TotalAmount   float64                `json:"total_amount"`
+	}
+
+	PriceOverrideResponse struct {
+		Price        float64 `json:"price"`
+		CatalogPrice float64 `json:"catalog_price"`
+		Status       string  `json:"status"`
+	}
+
+	ActorResponse struct {
+		Type string `json:"type"`
+		ID   string `json:"id"`
+	}
+)
+
+// NewQuoteResponse maps the quote, a missing number, name, address, payment or acceptance is rendered as null
+// and a quote without products as an empty list.
+func NewQuoteResponse(quote *types.Quote) QuoteResponse {
+	response := QuoteResponse{
+		ID:          quote.UUID,
+		Status:      string(quote.Status),
+		Revision:    quote.Revision,
+		UpdatedAt:   quote.UpdatedAt,
+		UpdatedBy:   NewActorResponse(quote.UpdatedBy),
+		Products:    make([]ProductResponse, 0, len(quote.Products)),
+		Amount:      quote.Amount,
+		TaxAmount:   quote.TaxAmount,
+		TotalAmount: quote.TotalAmount,
+	}
+
+	if quote.Number != "" {
+		response.Number = &quote.Number
+	}
+	if quote.Name != "" {
+		response.Name = &quote.Name
+	}
+	if quote.Address != nil {
+		response.Address = &AddressResponse{
+			Address: quote.Address.Address,
+			City:    quote.Address.City,
+			Country: quote.Address.Country,
+		}
+	}
+
+	if quote.Payment != nil {
+		response.Payment = &PaymentResponse{
+			PaymentMethod: quote.Payment.PaymentMethod,
+		}
+	}
+
+	if quote.Acceptance != nil {
+		response.Acceptance = &AcceptanceResponse{
+			Name:         quote.Acceptance.Name,
+			Email:        quote.Acceptance.Email,
+			AcceptedAt:   quote.Acceptance.AcceptedAt,
+			IP:           quote.Acceptance.IP,
+			UserAgent:    quote.Acceptance.UserAgent,
+			Revision:     quote.Acceptance.Revision,
+			RevisionHash: quote.Acceptance.RevisionHash,
+			TermsVersion: quote.Acceptance.TermsVersion,
+		}
+	}
+
+	for _, product := range quote.Products {
+		response.Products = append(response.Products, newProductResponse(product))
+	}
+
+	return response
+}
+
+// NewActorResponse maps the actor, a change without a known actor is rendered as null.
+func NewActorResponse(actor *types.Actor) *ActorResponse {
+	if actor == nil {
+		return nil
+	}
+
+	return &ActorResponse{
+		Type: string(actor.Type),
+		ID:   actor.ID,
+	}
+}
+
+func newProductResponse(product types.Product) ProductResponse {
+	response := ProductResponse{
+		ID:          product.ProductID,
+		Quantity:    product.Quantity,
+		Discount:    product.Discount,
+		Amount:      product.Amount,
+		TaxAmount:   product.TaxAmount,
+		TotalAmount: product.TotalAmount,
+	}
+
+	if product.Override != nil {
+		response.PriceOverride = &PriceOverrideResponse{
+			Price:        product.Override.Price,
+			CatalogPrice: product.Override.CatalogPrice,
+			Status:       string(product.Override.Status),
+		}
+	}
+
+	return response
+}
+
+// NewQuoteListResponse maps a page of quotes, the cursor of the next page is null on the last page.
+func NewQuoteListResponse(quotes []*types.Quote, nextCursor string) QuoteListResponse {
+	response := QuoteListResponse{
+		Quotes: make([]QuoteResponse, 0, len(quotes)),
+	}
+	for _, quote := range quotes {
+		response.Quotes = append(response.Quotes, NewQuoteResponse(quote))
+	}
+	if nextCursor != "" {
+		response.NextCursor = &nextCursor
+	}
+
+	return response
+}
+
+// NewDraftListResponse maps the drafts ordered with the active draft first, the active ID is null without drafts.
+func NewDraftListResponse(drafts []*types.Quote) DraftListResponse {
+	response := DraftListResponse{
+		Drafts: make([]QuoteResponse, 0, len(drafts)),
+	}
+
+	if len(drafts) > 0 {
+		response.ActiveID = &drafts[0].UUID
+	}
+	for _, draft := range drafts {
+		response.Drafts = append(response.Drafts, NewQuoteResponse(draft))
+	}
+
+	return response
+}
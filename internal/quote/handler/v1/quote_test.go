@@ -0,0 +1,85 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func assertGolden(t *testing.T, name string, response interface{}) {
+	actual, err := json.MarshalIndent(response, "", "  ")
+	assert.NoError(t, err)
+
+	path := filepath.Join("testdata", name+".golden.json")
+	if *update {
+		assert.NoError(t, os.WriteFile(path, append(actual, '\n'), 0o644))
+	}
+
+	expected, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(actual))
+}
+
+func TestNewQuoteResponseEmpty(t *testing.T) {
+	// arrange
+	quote := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.New())
+	quote.UpdatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// act
+	response := v1.NewQuoteResponse(quote)
+
+	// assert
+	assertGolden(t, "quote_empty", response)
+}
+
+func TestNewQuoteResponseFull(t *testing.T) {
+	// arrange
+	quote := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.New())
+	quote.Number = "Q-2026-000123"
+	quote.Name = "Kitchen renovation"
+	quote.UpdatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	quote.UpdatedBy = &types.Actor{Type: types.ActorTypeAgent, ID: "0c7e5a57-31a4-4b36-9a3b-8f1f6e0d2b11"}
+	quote.Revision = 3
+	quote.Address = &types.Address{Address: "Unter den Linden 1", City: "Berlin", Country: "DE"}
+	quote.Payment = &types.Payment{PaymentMethod: "invoice"}
+	quote.Products = []types.Product{
+		{
+			ProductID:   uuid.MustParse("f2d6a7a2-5a1b-4c47-8c1e-0a9b8c7d6e5f"),
+			Quantity:    2,
+			Amount:      200,
+			TaxAmount:   38,
+			TotalAmount: 238,
+		},
+		{
+			ProductID: uuid.MustParse("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+			Quantity:  1,
+			Discount:  10,
+			Override: &types.PriceOverride{
+				Price:        80,
+				CatalogPrice: 100,
+				Status:       types.ApprovalStatusPending,
+			},
+			Amount:      72,
+			TaxAmount:   13.68,
+			TotalAmount: 85.68,
+		},
+	}
+	quote.Amount, quote.TaxAmount, quote.TotalAmount = 272, 51.68, 323.68
+
+	// act
+	response := v1.NewQuoteResponse(quote)
+
+	// assert
+	assertGolden(t, "quote_full", response)
+}
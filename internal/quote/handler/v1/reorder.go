@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+type (
+	ReorderResponse struct {
+		Copied  []ProductOperationResultResponse `json:"copied"`
+		Skipped []ReorderSkipResponse            `json:"skipped"`
+		Quote   QuoteResponse                    `json:"quote"`
+	}
+
+	ReorderSkipResponse struct {
+		Index     int       `json:"index"`
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+		Reason    string    `json:"reason"`
+		Message   string    `json:"message"`
+	}
+)
+
+// NewReorderResponse maps the copied and skipped lines, both indexed like the lines of the source quote,
+// and the draft they were copied into.
+func NewReorderResponse(result *types.ReorderResult, quote *types.Quote) ReorderResponse {
+	response := ReorderResponse{
+		Copied:  make([]ProductOperationResultResponse, 0, len(result.Copied)),
+		Skipped: make([]ReorderSkipResponse, 0, len(result.Skipped)),
+		Quote:   NewQuoteResponse(quote),
+	}
+	for _, copied := range result.Copied {
+		response.Copied = append(response.Copied, ProductOperationResultResponse{
+			Index:     copied.Index,
+			Op:        string(copied.Type),
+			ProductID: copied.ProductID,
+			Quantity:  copied.Quantity,
+		})
+	}
+	for _, skipped := range result.Skipped {
+		response.Skipped = append(response.Skipped, ReorderSkipResponse{
+			Index:     skipped.Index,
+			ProductID: skipped.ProductID,
+			Quantity:  skipped.Quantity,
+			Reason:    string(skipped.Reason),
+			Message:   skipped.Message,
+		})
+	}
+
+	return response
+}
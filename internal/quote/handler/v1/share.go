@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+const (
+	ShareStatusActive  string = "active"
+	ShareStatusExpired string = "expired"
+	ShareStatusRevoked string = "revoked"
+)
+
+type (
+	ShareListResponse struct {
+		Shares []ShareResponse `json:"shares"`
+	}
+
+	ShareResponse struct {
+		ID        uuid.UUID      `json:"id"`
+		QuoteID   uuid.UUID      `json:"quote_id"`
+		Revision  int            `json:"revision"`
+		Status    string         `json:"status"`
+		CreatedAt time.Time      `json:"created_at"`
+		CreatedBy *ActorResponse `json:"created_by"`
+		ExpiresAt time.Time      `json:"expires_at"`
+		RevokedAt *time.Time     `json:"revoked_at"`
+	}
+
+	// CreatedShareResponse carries the token of the link, which is only returned when the share is created.
+	CreatedShareResponse struct {
+		ShareResponse
+		Token string `json:"token"`
+	}
+
+	ShareAccessListResponse struct {
+		Accesses []ShareAccessResponse `json:"accesses"`
+	}
+
+	ShareAccessResponse struct {
+		AccessedAt time.Time `json:"accessed_at"`
+		IP         string    `json:"ip"`
+		UserAgent  string    `json:"user_agent"`
+	}
+
+	// SharedRevisionResponse is the revision of a quote shown to anyone holding the share link.
+	SharedRevisionResponse struct {
+		Revision  int                 `json:"revision"`
+		ExpiresAt time.Time           `json:"expires_at"`
+		Quote     SharedQuoteResponse `json:"quote"`
+	}
+
+	// SharedQuoteResponse is the read-only view of a quote for people without an account. It leaves out the
+	// acceptance, the actors and the price overrides, which are personal or negotiation data.
+	SharedQuoteResponse struct {
+		Number      *string                      `json:"number"`
+		Status      string                       `json:"status"`
+		Address     *AddressResponse             `json:"address"`
+		Products    []SharedQuoteProductResponse `json:"products"`
+		Amount      float64                      `json:"amount"`
+		TaxAmount   float64                      `json:"tax_amount"`
+		TotalAmount float64                      `json:"total_amount"`
+	}
+
+	SharedQuoteProductResponse struct {
+		ID          uuid.UUID `json:"product_id"`
+		Quantity    int       `json:"qty"`
+		Discount    float64   `json:"discount_percent"`
+		Amount      float64   `json:"amount"`
+		TaxAmount   float64   `json:"tax_amount"`
+		TotalAmount float64   `json:"total_amount"`
+	}
+)
+
+// NewShareResponse maps the share with its status at the time, a share which is not revoked has a null revoked_at.
+func NewShareResponse(share *types.Share, now time.Time) ShareResponse {
+	response := ShareResponse{
+		ID:        share.UUID,
+		QuoteID:   share.QuoteID,
+		Revision:  share.Revision,
+		Status:    ShareStatusActive,
+		CreatedAt: share.CreatedAt,
+		CreatedBy: NewActorResponse(share.CreatedBy),
+		ExpiresAt: share.ExpiresAt,
+	}
+
+	switch {
+	case share.Revoked():
+		response.Status = ShareStatusRevoked
+		response.RevokedAt = &share.RevokedAt
+	case share.Expired(now):
+		response.Status = ShareStatusExpired
+	}
+
+	return response
+}
+
+func NewCreatedShareResponse(share *types.Share, token string, now time.Time) CreatedShareResponse {
+	return CreatedShareResponse{
+		ShareResponse: NewShareResponse(share, now),
+		Token:         token,
+	}
+}
+
+func NewShareListResponse(shares []*types.Share, now time.Time) ShareListResponse {
+	response := ShareListResponse{
+		Shares: make([]ShareResponse, 0, len(shares)),
+	}
+	for _, share := range shares {
+		response.Shares = append(response.Shares, NewShareResponse(share, now))
+	}
+
+	return response
+}
+
+func NewShareAccessListResponse(accesses []types.ShareAccess) ShareAccessListResponse {
+	response := ShareAccessListResponse{
+		Accesses: make([]ShareAccessResponse, 0, len(accesses)),
+	}
+	for _, access := range accesses {
+		response.Accesses = append(response.Accesses, ShareAccessResponse{
+			AccessedAt: access.AccessedAt,
+			IP:         access.IP,
+			UserAgent:  access.UserAgent,
+		})
+	}
+
+	return response
+}
+
+func NewSharedRevisionResponse(share *types.Share, revision *types.QuoteRevision) SharedRevisionResponse {
+	return SharedRevisionResponse{
+		Revision:  revision.Number,
+		ExpiresAt: share.ExpiresAt,
+		Quote:     NewSharedQuoteResponse(&revision.Quote),
+	}
+}
+
+// NewSharedQuoteResponse maps the lines, amounts, number, status and address of the quote, a missing number or
+// address is rendered as null.
+func NewSharedQuoteResponse(quote *types.Quote) SharedQuoteResponse {
+	response := SharedQuoteResponse{
+		Status:      string(quote.Status),
+		Products:    make([]SharedQuoteProductResponse, 0, len(quote.Products)),
+		Amount:      quote.Amount,
+		TaxAmount:   quote.TaxAmount,
+		TotalAmount: quote.TotalAmount,
+	}
+
+	if quote.Number != "" {
+		response.Number = &quote.Number
+	}
+	if quote.Address != nil {
+		response.Address = &AddressResponse{
+			Address: quote.Address.Address,
+			City:    quote.Address.City,
+			Country: quote.Address.Country,
+		}
+	}
+
+	for _, product := range quote.Products {
+		response.Products = append(response.Products, SharedQuoteProductResponse{
+			ID:          product.ProductID,
+			Quantity:    product.Quantity,
+			Discount:    product.Discount,
+			Amount:      product.Amount,
+			TaxAmount:   product.TaxAmount,
+			TotalAmount: product.TotalAmount,
+		})
+	}
+
+	return response
+}
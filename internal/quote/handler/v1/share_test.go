@@ -0,0 +1,67 @@
+package v1_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	v1 "app/internal/quote/handler/v1"
+	"app/internal/quote/types"
+)
+
+func TestNewSharedQuoteResponse(t *testing.T) {
+	// arrange
+	quote := types.NewQuote(uuid.MustParse("7b0f4c4e-1c9a-4e0e-9d3b-6f1f2a3b4c5d"), uuid.New())
+	quote.Number = "Q-2026-000123"
+	quote.Status = types.QuoteStatusAccepted
+	quote.UpdatedAt = time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	quote.UpdatedBy = &types.Actor{Type: types.ActorTypeAgent, ID: "0c7e5a57-31a4-4b36-9a3b-8f1f6e0d2b11"}
+	quote.Address = &types.Address{Address: "Unter den Linden 1", City: "Berlin", Country: "DE"}
+	quote.Payment = &types.Payment{PaymentMethod: "invoice"}
+	quote.Products = []types.Product{
+		{
+			ProductID: uuid.MustParse("a1b2c3d4-e5f6-4a7b-8c9d-0e1f2a3b4c5d"),
+			Quantity:  1,
+			Discount:  10,
+			Override: &types.PriceOverride{
+				Price:        80,
+				CatalogPrice: 100,
+				Status:       types.ApprovalStatusApproved,
+			},
+			Amount:      72,
+			TaxAmount:   13.68,
+			TotalAmount: 85.68,
+		},
+	}
+	quote.Amount, quote.TaxAmount, quote.TotalAmount = 72, 13.68, 85.68
+	quote.Acceptance = &types.Acceptance{
+		Name:         "Jane Doe",
+		Email:        "jane@example.com",
+		AcceptedAt:   time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		IP:           "203.0.113.7",
+		UserAgent:    "curl",
+		Revision:     3,
+		TermsVersion: "2026-01",
+	}
+
+	// act
+	response := v1.NewSharedQuoteResponse(quote)
+
+	// assert
+	assertGolden(t, "shared_quote", response)
+
+	// people holding the link must not learn who accepted or changed the quote, nor the catalog price
+	var fields map[string]interface{}
+	data, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(data, &fields))
+	for _, field := range []string{"id", "acceptance", "updated_by", "updated_at", "payment", "name"} {
+		assert.NotContains(t, fields, field)
+	}
+	assert.NotContains(t, fields["products"].([]interface{})[0], "price_override")
+	assert.NotContains(t, string(data), "catalog_price")
+	assert.NotContains(t, string(data), "jane@example.com")
+}
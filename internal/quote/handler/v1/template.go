@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"app/internal/quote/types"
+)
+
+type (
+	TemplateListResponse struct {
+		Templates []TemplateResponse `json:"templates"`
+	}
+
+	TemplateResponse struct {
+		ID          uuid.UUID              `json:"id"`
+		Name        string                 `json:"name"`
+		Description string                 `json:"description"`
+		Lines       []TemplateLineResponse `json:"lines"`
+		CreatedAt   time.Time              `json:"created_at"`
+		UpdatedAt   time.Time              `json:"updated_at"`
+		UpdatedBy   *ActorResponse         `json:"updated_by"`
+	}
+
+	TemplateLineResponse struct {
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+	}
+)
+
+func NewTemplateResponse(template *types.Template) TemplateResponse {
+	response := TemplateResponse{
+		ID:          template.UUID,
+		Name:        template.Name,
+		Description: template.Description,
+		Lines:       make([]TemplateLineResponse, 0, len(template.Lines)),
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+		UpdatedBy:   NewActorResponse(template.UpdatedBy),
+	}
+	for _, line := range template.Lines {
+		response.Lines = append(response.Lines, TemplateLineResponse{
+			ProductID: line.ProductID,
+			Quantity:  line.Quantity,
+		})
+	}
+
+	return response
+}
+
+func NewTemplateListResponse(templates []*types.Template) TemplateListResponse {
+	response := TemplateListResponse{
+		Templates: make([]TemplateResponse, 0, len(templates)),
+	}
+	for _, template := range templates {
+		response.Templates = append(response.Templates, NewTemplateResponse(template))
+	}
+
+	return response
+}
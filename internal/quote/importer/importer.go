@@ -0,0 +1,240 @@
+// Package importer reads quote lines from uploaded CSV and XLSX files.
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/xuri/excelize/v2"
+
+	"app/internal/quote/types"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+const (
+	// MaxRows is the largest number of rows of a file, the header included. Further rows are not read.
+	MaxRows int = 10000
+	// maxUnzippedBytes limits the size of a workbook once it is decompressed.
+	maxUnzippedBytes int64 = 100 << 20
+)
+
+const (
+	columnProductID string = "product_id"
+	columnSKU       string = "sku"
+	columnQuantity  string = "qty"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("import file format is not supported")
+	ErrInvalidFile       = errors.New("import file can not be read")
+)
+
+// columnAliases maps the accepted header names to the columns.
+var columnAliases = map[string]string{
+	"product_id": columnProductID,
+	"product id": columnProductID,
+	"sku":        columnSKU,
+	"qty":        columnQuantity,
+	"quantity":   columnQuantity,
+}
+
+// FormatFromFilename returns the format by the file extension.
+func FormatFromFilename(name string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+
+	return "", fmt.Errorf("Importer::FormatFromFilename : %w: %q", ErrUnsupportedFormat, filepath.Ext(name))
+}
+
+// Parse reads the quote lines of the file. The first row is the header naming the product_id or sku column
+// and the qty column, empty rows are skipped. Cells which can not be read are returned as a
+// types.ValidationError with the fields `rows.<row>.<column>`.
+func Parse(r io.Reader, format Format) ([]types.ImportLine, error) {
+	var (
+		rows [][]string
+		err  error
+	)
+	switch format {
+	case FormatCSV:
+		rows, err = readCSV(r)
+	case FormatXLSX:
+		rows, err = readXLSX(r)
+	default:
+		return nil, fmt.Errorf("Importer::Parse : %w: %q", ErrUnsupportedFormat, format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Importer::Parse : %w: %w", ErrInvalidFile, err)
+	}
+
+	lines, err := parseRows(rows)
+	if err != nil {
+		return nil, fmt.Errorf("Importer::Parse : %w", err)
+	}
+
+	return lines, nil
+}
+
+// readCSV reads up to one row more than MaxRows, so a longer file is detected without reading it all.
+func readCSV(r io.Reader) ([][]string, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	rows := make([][]string, 0)
+	for len(rows) <= MaxRows {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+// readXLSX reads the first sheet of the workbook up to one row more than MaxRows.
+func readXLSX(r io.Reader) ([][]string, error) {
+	file, err := excelize.OpenReader(r, excelize.Options{UnzipSizeLimit: maxUnzippedBytes})
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	sheets := file.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, errors.New("workbook has no sheets")
+	}
+
+	sheet, err := file.Rows(sheets[0])
+	if err != nil {
+		return nil, err
+	}
+	defer sheet.Close()
+
+	rows := make([][]string, 0)
+	for len(rows) <= MaxRows && sheet.Next() {
+		row, err := sheet.Columns()
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	if err := sheet.Error(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+func parseRows(rows [][]string) ([]types.ImportLine, error) {
+	validation := &types.ValidationError{}
+	if len(rows) == 0 {
+		validation.Add("rows", "file is empty")
+		return nil, validation
+	}
+	if len(rows) > MaxRows {
+		validation.Add("rows", fmt.Sprintf("file can not have more than %d rows", MaxRows))
+		return nil, validation
+	}
+
+	columns := make(map[string]int)
+	for i, name := range rows[0] {
+		if column, ok := columnAliases[strings.ToLower(strings.TrimSpace(name))]; ok {
+			columns[column] = i
+		}
+	}
+
+	_, hasProductID := columns[columnProductID]
+	_, hasSKU := columns[columnSKU]
+	if !hasProductID && !hasSKU {
+		validation.Add("rows.1", "header must name a product_id or sku column")
+	}
+	if _, ok := columns[columnQuantity]; !ok {
+		validation.Add("rows.1", "header must name a qty column")
+	}
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+
+	lines := make([]types.ImportLine, 0, len(rows)-1)
+	for i, row := range rows[1:] {
+		number := i + 2
+		if isEmpty(row) {
+			continue
+		}
+
+		line := types.ImportLine{
+			Row: number,
+			SKU: cell(row, columns, columnSKU),
+		}
+		field := func(column string) string {
+			return fmt.Sprintf("rows.%d.%s", number, column)
+		}
+
+		if productID := cell(row, columns, columnProductID); productID != "" {
+			parsed, err := uuid.Parse(productID)
+			if err != nil {
+				validation.Add(field(columnProductID), "product id must be a UUID")
+			}
+			line.ProductID = parsed
+		} else if line.SKU == "" {
+			validation.Add(field(columnProductID), "product id or sku is required")
+		}
+
+		quantity, err := strconv.Atoi(cell(row, columns, columnQuantity))
+		if err != nil {
+			validation.Add(field(columnQuantity), "quantity must be a whole number")
+		}
+		line.Quantity = quantity
+
+		lines = append(lines, line)
+	}
+
+	if err := validation.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		validation.Add("rows", "file has no lines")
+		return nil, validation
+	}
+
+	return lines, nil
+}
+
+func cell(row []string, columns map[string]int, column string) string {
+	i, ok := columns[column]
+	if !ok || i >= len(row) {
+		return ""
+	}
+
+	return strings.TrimSpace(row[i])
+}
+
+func isEmpty(row []string) bool {
+	for _, value := range row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,103 @@
+package importer_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/xuri/excelize/v2"
+
+	"app/internal/quote/importer"
+	"app/internal/quote/types"
+)
+
+func TestParseCSV(t *testing.T) {
+	// arrange
+	productUUID := uuid.New()
+	content := "Product ID, SKU, Quantity\n" +
+		productUUID.String() + ",,2\n" +
+		",,\n" +
+		", BOLT-M8 , 10\n"
+
+	// act
+	lines, err := importer.Parse(strings.NewReader(content), importer.FormatCSV)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ImportLine{
+		{Row: 2, ProductID: productUUID, Quantity: 2},
+		{Row: 4, SKU: "BOLT-M8", Quantity: 10},
+	}, lines)
+}
+
+func TestParseXLSX(t *testing.T) {
+	// arrange
+	file := excelize.NewFile()
+	sheet := file.GetSheetName(0)
+	assert.NoError(t, file.SetSheetRow(sheet, "A1", &[]interface{}{"sku", "qty"}))
+	assert.NoError(t, file.SetSheetRow(sheet, "A2", &[]interface{}{"BOLT-M8", 3}))
+	assert.NoError(t, file.SetSheetRow(sheet, "A3", &[]interface{}{"NUT-M8", 4}))
+
+	var content bytes.Buffer
+	assert.NoError(t, file.Write(&content))
+
+	// act
+	lines, err := importer.Parse(&content, importer.FormatXLSX)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []types.ImportLine{
+		{Row: 2, SKU: "BOLT-M8", Quantity: 3},
+		{Row: 3, SKU: "NUT-M8", Quantity: 4},
+	}, lines)
+}
+
+func TestParseMissingColumns(t *testing.T) {
+	// act
+	lines, err := importer.Parse(strings.NewReader("name,amount\nbolt,1\n"), importer.FormatCSV)
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, lines)
+	assert.Equal(t, []types.FieldError{
+		{Field: "rows.1", Message: "header must name a product_id or sku column"},
+		{Field: "rows.1", Message: "header must name a qty column"},
+	}, validationError.Fields)
+}
+
+func TestParseTooManyRows(t *testing.T) {
+	// arrange
+	content := "sku,qty\n" + strings.Repeat("BOLT-M8,1\n", importer.MaxRows)
+
+	// act
+	lines, err := importer.Parse(strings.NewReader(content), importer.FormatCSV)
+
+	// assert
+	var validationError *types.ValidationError
+	assert.ErrorAs(t, err, &validationError)
+	assert.Nil(t, lines)
+	assert.Equal(t, []types.FieldError{
+		{Field: "rows", Message: fmt.Sprintf("file can not have more than %d rows", importer.MaxRows)},
+	}, validationError.Fields)
+}
+
+func TestParseInvalidFile(t *testing.T) {
+	// act
+	_, err := importer.Parse(strings.NewReader("not a workbook"), importer.FormatXLSX)
+
+	// assert
+	assert.ErrorIs(t, err, importer.ErrInvalidFile)
+}
+
+func TestFormatFromFilename(t *testing.T) {
+	format, err := importer.FormatFromFilename("Order.XLSX")
+	assert.NoError(t, err)
+	assert.Equal(t, importer.FormatXLSX, format)
+
+	_, err = importer.FormatFromFilename("order.ods")
+	assert.ErrorIs(t, err, importer.ErrUnsupportedFormat)
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 )
 
+// DynamoQuote is the placeholder of the DynamoDB snapshot store, none of its operations is implemented yet. It is
+// only selected with QUOTE_PERSISTENCE=snapshot, quotes are kept in the event store by default.
 type DynamoQuote struct {
 }
 
@@ -19,6 +21,36 @@ func (d *DynamoQuote) FindByCustomerAndStatus(ctx context.Context, customerUUID
 	return nil, errors.New("not implemented")
 }
 
+func (d *DynamoQuote) FindByID(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *DynamoQuote) FindByNumber(ctx context.Context, number string) (*types.Quote, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *DynamoQuote) NextQuoteNumber(ctx context.Context, tenant string, year int) (int, error) {
+	return 0, errors.New("not implemented")
+}
+
 func (d *DynamoQuote) Save(ctx context.Context, quote *types.Quote) error {
 	return errors.New("not implemented")
 }
+
+func (d *DynamoQuote) FindRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *DynamoQuote) FindRevision(ctx context.Context, quoteUUID uuid.UUID, number int) (*types.QuoteRevision, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (d *DynamoQuote) ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error {
+	return errors.New("not implemented")
+}
+
+// FindQuotes would need an index of the customer and the update time to page through the history of a customer,
+// the table has none yet.
+func (d *DynamoQuote) FindQuotes(ctx context.Context, query types.QuoteQuery) (*types.QuotePage, error) {
+	return nil, errors.New("not implemented")
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// QuoteIndex is the lookup data of a quote stream kept next to its events.
+	QuoteIndex struct {
+		Number     string
+		CustomerID uuid.UUID
+		Status     types.QuoteStatus
+		CreatedAt  time.Time
+		UpdatedAt  time.Time
+		// ActivatedAt orders the drafts of a customer, the most recently activated is found first.
+		ActivatedAt time.Time
+	}
+
+	eventStore interface {
+		Append(ctx context.Context, quoteUUID uuid.UUID, expectedVersion int, events []types.Event, index QuoteIndex) error
+		Load(ctx context.Context, quoteUUID uuid.UUID, afterVersion int) ([]types.Event, error)
+		LoadSnapshot(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error)
+		SaveSnapshot(ctx context.Context, quote *types.Quote) error
+		FindQuoteID(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (uuid.UUID, error)
+		FindQuoteIDs(ctx context.Context, filter types.QuoteFilter) ([]uuid.UUID, error)
+		FindQuotePage(ctx context.Context, query types.QuoteQuery) ([]uuid.UUID, *types.QuoteCursor, error)
+		FindQuoteIDByNumber(ctx context.Context, number string) (uuid.UUID, error)
+		NextNumber(ctx context.Context, tenant string, year int) (int, error)
+	}
+
+	// EventSourcedQuote stores the events recorded by the quote operations as the source of truth
+	// and rebuilds quotes by folding them. Every snapshotInterval events a snapshot is stored,
+	// so loading does not replay the whole stream.
+	EventSourcedQuote struct {
+		store            eventStore
+		snapshotInterval int
+	}
+)
+
+func NewEventSourcedQuote(store eventStore, snapshotInterval int) *EventSourcedQuote {
+	return &EventSourcedQuote{
+		store:            store,
+		snapshotInterval: snapshotInterval,
+	}
+}
+
+// FindByCustomerAndStatus finds the quote of the customer in the status, of several quotes the most recently
+// activated one, e.g. the active draft.
+func (e *EventSourcedQuote) FindByCustomerAndStatus(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (*types.Quote, error) {
+	quoteUUID, err := e.store.FindQuoteID(ctx, customerUUID, status)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindByCustomerAndStatus : %w", err)
+	}
+
+	quote, err := e.load(ctx, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindByCustomerAndStatus : %w", err)
+	}
+
+	return quote, nil
+}
+
+func (e *EventSourcedQuote) FindByID(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	quote, err := e.load(ctx, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindByID : %w", err)
+	}
+
+	return quote, nil
+}
+
+func (e *EventSourcedQuote) FindByNumber(ctx context.Context, number string) (*types.Quote, error) {
+	quoteUUID, err := e.store.FindQuoteIDByNumber(ctx, number)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindByNumber : %w", err)
+	}
+
+	quote, err := e.load(ctx, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindByNumber : %w", err)
+	}
+
+	return quote, nil
+}
+
+func (e *EventSourcedQuote) NextQuoteNumber(ctx context.Context, tenant string, year int) (int, error) {
+	sequence, err := e.store.NextNumber(ctx, tenant, year)
+	if err != nil {
+		return 0, fmt.Errorf("Repository::EventSourcedQuote::NextQuoteNumber : %w", err)
+	}
+
+	return sequence, nil
+}
+
+// ScanQuotes calls fn with every quote of the filter, ordered by the last change. Only the quote IDs are
+// selected at once, every quote is loaded right before it is passed to fn. An error of fn stops the scan.
+func (e *EventSourcedQuote) ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error {
+	quoteUUIDs, err := e.store.FindQuoteIDs(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("Repository::EventSourcedQuote::ScanQuotes : %w", err)
+	}
+
+	for _, quoteUUID := range quoteUUIDs {
+		quote, err := e.load(ctx, quoteUUID)
+		if err != nil {
+			return fmt.Errorf("Repository::EventSourcedQuote::ScanQuotes : %w", err)
+		}
+
+		if err := fn(quote); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindQuotes selects the IDs of a page of the query from the index and loads the quotes of the page.
+func (e *EventSourcedQuote) FindQuotes(ctx context.Context, query types.QuoteQuery) (*types.QuotePage, error) {
+	quoteUUIDs, next, err := e.store.FindQuotePage(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindQuotes : %w", err)
+	}
+
+	page := &types.QuotePage{
+		Quotes: make([]*types.Quote, 0, len(quoteUUIDs)),
+		Next:   next,
+	}
+	for _, quoteUUID := range quoteUUIDs {
+		quote, err := e.load(ctx, quoteUUID)
+		if err != nil {
+			return nil, fmt.Errorf("Repository::EventSourcedQuote::FindQuotes : %w", err)
+		}
+		page.Quotes = append(page.Quotes, quote)
+	}
+
+	return page, nil
+}
+
+// load folds the events recorded after the latest snapshot of the quote into the snapshot.
+func (e *EventSourcedQuote) load(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	snapshot, err := e.store.LoadSnapshot(ctx, quoteUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	afterVersion := 0
+	if snapshot != nil {
+		afterVersion = snapshot.Version
+	}
+
+	events, err := e.store.Load(ctx, quoteUUID, afterVersion)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot == nil && len(events) == 0 {
+		return nil, types.ErrQuoteNotFound
+	}
+
+	return types.FoldQuote(snapshot, events), nil
+}
+
+func (e *EventSourcedQuote) Save(ctx context.Context, quote *types.Quote) error {
+	if len(quote.Changes) == 0 {
+		return nil
+	}
+
+	expectedVersion := quote.Changes[0].Version - 1
+	index := QuoteIndex{
+		Number:      quote.Number,
+		CustomerID:  quote.CustomerID,
+		Status:      quote.Status,
+		CreatedAt:   quote.CreatedAt,
+		UpdatedAt:   quote.UpdatedAt,
+		ActivatedAt: quote.ActivatedAt,
+	}
+
+	if err := e.store.Append(ctx, quote.UUID, expectedVersion, quote.Changes, index); err != nil {
+		return fmt.Errorf("Repository::EventSourcedQuote::Save : %w", err)
+	}
+
+	if e.snapshotInterval > 0 && quote.Version/e.snapshotInterval > expectedVersion/e.snapshotInterval {
+		if err := e.store.SaveSnapshot(ctx, copyQuote(*quote)); err != nil {
+			return fmt.Errorf("Repository::EventSourcedQuote::Save : %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (e *EventSourcedQuote) FindRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error) {
+	revisions, err := e.replayRevisions(ctx, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindRevisions : %w", err)
+	}
+
+	return revisions, nil
+}
+
+func (e *EventSourcedQuote) FindRevision(ctx context.Context, quoteUUID uuid.UUID, number int) (*types.QuoteRevision, error) {
+	revisions, err := e.replayRevisions(ctx, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::EventSourcedQuote::FindRevision : %w", err)
+	}
+
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return &revision, nil
+		}
+	}
+
+	return nil, types.ErrQuoteRevisionNotFound
+}
+
+// replayRevisions folds the whole stream and takes a copy of the quote after the last event of every revision.
+func (e *EventSourcedQuote) replayRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error) {
+	events, err := e.store.Load(ctx, quoteUUID, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]types.QuoteRevision, 0)
+	quote := &types.Quote{}
+	for i, event := range events {
+		quote.Apply(event)
+
+		if i+1 < len(events) && events[i+1].Revision == event.Revision {
+			continue
+		}
+
+		revisions = append(revisions, types.QuoteRevision{
+			QuoteID:   quoteUUID,
+			Number:    event.Revision,
+			CreatedAt: event.OccurredAt,
+			CreatedBy: event.Actor,
+			Quote:     *copyQuote(*quote),
+		})
+	}
+
+	return revisions, nil
+}
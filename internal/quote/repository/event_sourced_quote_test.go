@@ -0,0 +1,259 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"app/internal/quote/repository"
+	"app/internal/quote/types"
+)
+
+// commit stamps the recorded changes like the domain does before saving.
+func commit(t *testing.T, repo *repository.EventSourcedQuote, quote *types.Quote) {
+	quote.Revision++
+	quote.UpdatedAt = time.Now().UTC()
+	for i := range quote.Changes {
+		quote.Changes[i].Revision = quote.Revision
+		quote.Changes[i].OccurredAt = quote.UpdatedAt
+	}
+
+	assert.NoError(t, repo.Save(context.Background(), quote))
+	quote.Changes = nil
+}
+
+func newRecordedQuote(customerUUID uuid.UUID, productUUID uuid.UUID) *types.Quote {
+	quote := types.NewQuote(uuid.New(), customerUUID)
+	quote.Record(types.Event{Data: types.QuoteCreated{CustomerID: customerUUID, CreatedAt: quote.CreatedAt}})
+	quote.Record(types.Event{Data: types.ProductAdded{ProductID: productUUID, Quantity: 2}})
+	quote.Record(types.Event{Data: types.QuoteRepriced{
+		Lines:       []types.LinePrice{{ProductID: productUUID, Amount: 20, TaxAmount: 2, TotalAmount: 22}},
+		Amount:      20,
+		TaxAmount:   2,
+		TotalAmount: 22,
+	}})
+
+	return quote
+}
+
+func TestEventSourcedQuoteFold(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 2)
+	customerUUID, productUUID := uuid.New(), uuid.New()
+
+	quote := newRecordedQuote(customerUUID, productUUID)
+	commit(t, repo, quote)
+
+	quote.Record(types.Event{Data: types.ProductQuantityChanged{ProductID: productUUID, Quantity: 5}})
+	quote.Record(types.Event{Data: types.AddressSaved{Address: &types.Address{City: "Berlin"}}})
+	commit(t, repo, quote)
+
+	// act
+	actual, err := repo.FindByCustomerAndStatus(ctx, customerUUID, types.QuoteStatusDraft)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, quote.UUID, actual.UUID)
+	assert.Equal(t, 5, actual.Version)
+	assert.Equal(t, 2, actual.Revision)
+	assert.Equal(t, 5, actual.Products[0].Quantity)
+	assert.Equal(t, 22.0, actual.TotalAmount)
+	assert.Equal(t, &types.Address{City: "Berlin"}, actual.Address)
+}
+
+func TestEventSourcedQuoteVersionConflict(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+	customerUUID, productUUID := uuid.New(), uuid.New()
+
+	quote := newRecordedQuote(customerUUID, productUUID)
+	commit(t, repo, quote)
+
+	first, err := repo.FindByCustomerAndStatus(ctx, customerUUID, types.QuoteStatusDraft)
+	assert.NoError(t, err)
+	second, err := repo.FindByCustomerAndStatus(ctx, customerUUID, types.QuoteStatusDraft)
+	assert.NoError(t, err)
+
+	first.Record(types.Event{Data: types.PaymentSaved{Payment: &types.Payment{PaymentMethod: "invoice"}}})
+	assert.NoError(t, repo.Save(ctx, first))
+
+	// act
+	second.Record(types.Event{Data: types.PaymentSaved{Payment: &types.Payment{PaymentMethod: "card"}}})
+	err = repo.Save(ctx, second)
+
+	// assert
+	assert.ErrorIs(t, err, types.ErrQuoteVersionConflict)
+}
+
+func TestEventSourcedQuoteRevisions(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+	customerUUID, productUUID := uuid.New(), uuid.New()
+
+	quote := newRecordedQuote(customerUUID, productUUID)
+	commit(t, repo, quote)
+
+	quote.Record(types.Event{Data: types.ProductQuantityChanged{ProductID: productUUID, Quantity: 7}})
+	commit(t, repo, quote)
+
+	// act
+	revisions, err := repo.FindRevisions(ctx, quote.UUID)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 2)
+	assert.Equal(t, 2, revisions[0].Quote.Products[0].Quantity)
+	assert.Equal(t, 7, revisions[1].Quote.Products[0].Quantity)
+}
+
+func TestEventSourcedQuoteScanQuotes(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+	customerUUID := uuid.New()
+
+	first := newRecordedQuote(customerUUID, uuid.New())
+	commit(t, repo, first)
+	second := newRecordedQuote(uuid.New(), uuid.New())
+	commit(t, repo, second)
+	third := newRecordedQuote(customerUUID, uuid.New())
+	commit(t, repo, third)
+
+	scan := func(filter types.QuoteFilter) []uuid.UUID {
+		quoteUUIDs := make([]uuid.UUID, 0)
+		err := repo.ScanQuotes(ctx, filter, func(quote *types.Quote) error {
+			assert.Equal(t, 22.0, quote.TotalAmount)
+			quoteUUIDs = append(quoteUUIDs, quote.UUID)
+			return nil
+		})
+		assert.NoError(t, err)
+
+		return quoteUUIDs
+	}
+
+	// act
+	all := scan(types.QuoteFilter{})
+	customer := scan(types.QuoteFilter{CustomerID: customerUUID})
+	since := scan(types.QuoteFilter{UpdatedFrom: second.UpdatedAt})
+	done := scan(types.QuoteFilter{Statuses: []types.QuoteStatus{types.QuoteStatusDone}})
+
+	// assert
+	assert.Equal(t, []uuid.UUID{first.UUID, second.UUID, third.UUID}, all)
+	assert.Equal(t, []uuid.UUID{first.UUID, third.UUID}, customer)
+	assert.Equal(t, []uuid.UUID{second.UUID, third.UUID}, since)
+	assert.Empty(t, done)
+}
+
+func TestEventSourcedQuoteFindByNumber(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+
+	quote := newRecordedQuote(uuid.New(), uuid.New())
+	quote.Record(types.Event{Data: types.QuoteNumbered{Number: "Q-2026-000001"}})
+	commit(t, repo, quote)
+
+	// act
+	actual, err := repo.FindByNumber(ctx, "Q-2026-000001")
+	_, errMissing := repo.FindByNumber(ctx, "Q-2026-000002")
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, quote.UUID, actual.UUID)
+	assert.Equal(t, "Q-2026-000001", actual.Number)
+	assert.ErrorIs(t, errMissing, types.ErrQuoteNotFound)
+}
+
+func TestEventSourcedQuoteFindActiveDraft(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+	customerUUID := uuid.New()
+
+	unnamed := newRecordedQuote(customerUUID, uuid.New())
+	commit(t, repo, unnamed)
+
+	kitchen := newRecordedQuote(customerUUID, uuid.New())
+	kitchen.Record(types.Event{Data: types.DraftActivated{ActivatedAt: time.Now().Add(-time.Hour)}})
+	commit(t, repo, kitchen)
+
+	bathroom := newRecordedQuote(customerUUID, uuid.New())
+	bathroom.Record(types.Event{Data: types.DraftActivated{ActivatedAt: time.Now().Add(-2 * time.Hour)}})
+	commit(t, repo, bathroom)
+
+	// act
+	active, err := repo.FindByCustomerAndStatus(ctx, customerUUID, types.QuoteStatusDraft)
+
+	bathroom.Record(types.Event{Data: types.DraftActivated{ActivatedAt: time.Now()}})
+	commit(t, repo, bathroom)
+	reactivated, errReactivated := repo.FindByCustomerAndStatus(ctx, customerUUID, types.QuoteStatusDraft)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, kitchen.UUID, active.UUID)
+	assert.NoError(t, errReactivated)
+	assert.Equal(t, bathroom.UUID, reactivated.UUID)
+}
+
+func TestEventSourcedQuoteNextQuoteNumber(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+
+	// act
+	first, _ := repo.NextQuoteNumber(ctx, "meisterwerk", 2026)
+	second, _ := repo.NextQuoteNumber(ctx, "meisterwerk", 2026)
+	nextYear, _ := repo.NextQuoteNumber(ctx, "meisterwerk", 2027)
+	otherTenant, err := repo.NextQuoteNumber(ctx, "other", 2026)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 1, 1}, []int{first, second, nextYear, otherTenant})
+}
+
+func TestEventSourcedQuoteFindQuotes(t *testing.T) {
+	// arrange
+	ctx := context.Background()
+	repo := repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), 0)
+	customerUUID := uuid.New()
+
+	first := newRecordedQuote(customerUUID, uuid.New())
+	commit(t, repo, first)
+	commit(t, repo, newRecordedQuote(uuid.New(), uuid.New()))
+	second := newRecordedQuote(customerUUID, uuid.New())
+	commit(t, repo, second)
+	third := newRecordedQuote(customerUUID, uuid.New())
+	commit(t, repo, third)
+
+	ids := func(page *types.QuotePage) []uuid.UUID {
+		quoteUUIDs := make([]uuid.UUID, 0)
+		for _, quote := range page.Quotes {
+			quoteUUIDs = append(quoteUUIDs, quote.UUID)
+		}
+
+		return quoteUUIDs
+	}
+	filter := types.QuoteFilter{CustomerID: customerUUID}
+
+	// act
+	firstPage, err := repo.FindQuotes(ctx, types.QuoteQuery{Filter: filter, Limit: 2})
+	assert.NoError(t, err)
+	lastPage, err := repo.FindQuotes(ctx, types.QuoteQuery{Filter: filter, Limit: 2, After: firstPage.Next})
+	assert.NoError(t, err)
+	created, err := repo.FindQuotes(ctx, types.QuoteQuery{Filter: filter, Sort: types.QuoteSortCreatedAtAsc})
+	assert.NoError(t, err)
+
+	// assert
+	assert.Equal(t, []uuid.UUID{third.UUID, second.UUID}, ids(firstPage))
+	assert.NotNil(t, firstPage.Next)
+	assert.Equal(t, []uuid.UUID{first.UUID}, ids(lastPage))
+	assert.Nil(t, lastPage.Next)
+	assert.Equal(t, []uuid.UUID{first.UUID, second.UUID, third.UUID}, ids(created))
+	assert.Equal(t, 22.0, created.Quotes[0].TotalAmount)
+}
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryEventStore keeps quote events in process memory. It is meant for tests and local development.
+type MemoryEventStore struct {
+	mu        sync.RWMutex
+	streams   map[uuid.UUID][]types.Event
+	indexes   map[uuid.UUID]QuoteIndex
+	snapshots map[uuid.UUID]types.Quote
+	counters  map[numberCounter]int
+}
+
+func NewMemoryEventStore() *MemoryEventStore {
+	return &MemoryEventStore{
+		streams:   make(map[uuid.UUID][]types.Event),
+		indexes:   make(map[uuid.UUID]QuoteIndex),
+		snapshots: make(map[uuid.UUID]types.Quote),
+		counters:  make(map[numberCounter]int),
+	}
+}
+
+func (m *MemoryEventStore) Append(ctx context.Context, quoteUUID uuid.UUID, expectedVersion int, events []types.Event, index QuoteIndex) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.streams[quoteUUID]) != expectedVersion {
+		return types.ErrQuoteVersionConflict
+	}
+
+	m.streams[quoteUUID] = append(m.streams[quoteUUID], events...)
+	m.indexes[quoteUUID] = index
+
+	return nil
+}
+
+func (m *MemoryEventStore) Load(ctx context.Context, quoteUUID uuid.UUID, afterVersion int) ([]types.Event, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stream := m.streams[quoteUUID]
+	if afterVersion >= len(stream) {
+		return nil, nil
+	}
+
+	return slices.Clone(stream[afterVersion:]), nil
+}
+
+func (m *MemoryEventStore) LoadSnapshot(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	snapshot, ok := m.snapshots[quoteUUID]
+	if !ok {
+		return nil, nil
+	}
+
+	return copyQuote(snapshot), nil
+}
+
+func (m *MemoryEventStore) SaveSnapshot(ctx context.Context, quote *types.Quote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.snapshots[quote.UUID] = *copyQuote(*quote)
+
+	return nil
+}
+
+func (m *MemoryEventStore) FindQuoteID(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var found *types.Quote
+	for quoteUUID, index := range m.indexes {
+		if index.CustomerID == customerUUID && index.Status == status {
+			quote := indexedQuote(quoteUUID, index)
+			if found == nil || types.CompareActivation(quote, found) < 0 {
+				found = quote
+			}
+		}
+	}
+	if found == nil {
+		return uuid.UUID{}, types.ErrQuoteNotFound
+	}
+
+	return found.UUID, nil
+}
+
+func (m *MemoryEventStore) FindQuoteIDs(ctx context.Context, filter types.QuoteFilter) ([]uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	quotes := make([]*types.Quote, 0)
+	for quoteUUID, index := range m.indexes {
+		quote := indexedQuote(quoteUUID, index)
+		if filter.Matches(quote) {
+			quotes = append(quotes, quote)
+		}
+	}
+	sortByUpdatedAt(quotes)
+
+	quoteUUIDs := make([]uuid.UUID, 0, len(quotes))
+	for _, quote := range quotes {
+		quoteUUIDs = append(quoteUUIDs, quote.UUID)
+	}
+
+	return quoteUUIDs, nil
+}
+
+func (m *MemoryEventStore) FindQuotePage(ctx context.Context, query types.QuoteQuery) ([]uuid.UUID, *types.QuoteCursor, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	quotes := make([]*types.Quote, 0)
+	for quoteUUID, index := range m.indexes {
+		quote := indexedQuote(quoteUUID, index)
+		if query.Filter.Matches(quote) {
+			quotes = append(quotes, quote)
+		}
+	}
+	page := query.Page(quotes)
+
+	quoteUUIDs := make([]uuid.UUID, 0, len(page.Quotes))
+	for _, quote := range page.Quotes {
+		quoteUUIDs = append(quoteUUIDs, quote.UUID)
+	}
+
+	return quoteUUIDs, page.Next, nil
+}
+
+func (m *MemoryEventStore) FindQuoteIDByNumber(ctx context.Context, number string) (uuid.UUID, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for quoteUUID, index := range m.indexes {
+		if index.Number != "" && index.Number == number {
+			return quoteUUID, nil
+		}
+	}
+
+	return uuid.UUID{}, types.ErrQuoteNotFound
+}
+
+func (m *MemoryEventStore) NextNumber(ctx context.Context, tenant string, year int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counter := numberCounter{tenant: tenant, year: year}
+	m.counters[counter]++
+
+	return m.counters[counter], nil
+}
+
+// indexedQuote is a quote holding only the index data, enough to filter and order quotes without their events.
+func indexedQuote(quoteUUID uuid.UUID, index QuoteIndex) *types.Quote {
+	return &types.Quote{
+		UUID:        quoteUUID,
+		Number:      index.Number,
+		CustomerID:  index.CustomerID,
+		Status:      index.Status,
+		CreatedAt:   index.CreatedAt,
+		UpdatedAt:   index.UpdatedAt,
+		ActivatedAt: index.ActivatedAt,
+	}
+}
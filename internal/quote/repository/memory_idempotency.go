@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryIdempotency keeps idempotency records in process memory. It is meant for tests and local development.
+type MemoryIdempotency struct {
+	mu      sync.Mutex
+	records map[string]types.IdempotencyRecord
+}
+
+func NewMemoryIdempotency() *MemoryIdempotency {
+	return &MemoryIdempotency{
+		records: make(map[string]types.IdempotencyRecord),
+	}
+}
+
+// Reserve stores the record unless a not expired record with the same key exists, which is returned instead.
+func (m *MemoryIdempotency) Reserve(ctx context.Context, record *types.IdempotencyRecord) (*types.IdempotencyRecord, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.records[record.Key]; ok && existing.ExpiresAt.After(time.Now()) {
+		return &existing, nil
+	}
+
+	m.records[record.Key] = *record
+
+	return nil, nil
+}
+
+func (m *MemoryIdempotency) Complete(ctx context.Context, record *types.IdempotencyRecord) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.records[record.Key] = *record
+
+	return nil
+}
+
+func (m *MemoryIdempotency) Release(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.records, key)
+
+	return nil
+}
@@ -0,0 +1,218 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// numberCounter identifies the sequence of quote numbers of a tenant in a year.
+	numberCounter struct {
+		tenant string
+		year   int
+	}
+
+	// MemoryQuote keeps quotes in process memory. It is meant for tests and local development.
+	MemoryQuote struct {
+		mu        sync.RWMutex
+		quotes    map[uuid.UUID]types.Quote
+		revisions map[uuid.UUID][]types.QuoteRevision
+		counters  map[numberCounter]int
+	}
+)
+
+func NewMemoryQuote() *MemoryQuote {
+	return &MemoryQuote{
+		quotes:    make(map[uuid.UUID]types.Quote),
+		revisions: make(map[uuid.UUID][]types.QuoteRevision),
+		counters:  make(map[numberCounter]int),
+	}
+}
+
+func (m *MemoryQuote) FindByCustomerAndStatus(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (*types.Quote, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var found *types.Quote
+	for _, quote := range m.quotes {
+		if quote.CustomerID == customerUUID && quote.Status == status {
+			if found == nil || types.CompareActivation(&quote, found) < 0 {
+				found = copyQuote(quote)
+			}
+		}
+	}
+	if found == nil {
+		return nil, types.ErrQuoteNotFound
+	}
+
+	return found, nil
+}
+
+func (m *MemoryQuote) FindByID(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	quote, ok := m.quotes[quoteUUID]
+	if !ok {
+		return nil, types.ErrQuoteNotFound
+	}
+
+	return copyQuote(quote), nil
+}
+
+func (m *MemoryQuote) FindByNumber(ctx context.Context, number string) (*types.Quote, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, quote := range m.quotes {
+		if quote.Number != "" && quote.Number == number {
+			return copyQuote(quote), nil
+		}
+	}
+
+	return nil, types.ErrQuoteNotFound
+}
+
+func (m *MemoryQuote) NextQuoteNumber(ctx context.Context, tenant string, year int) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	counter := numberCounter{tenant: tenant, year: year}
+	m.counters[counter]++
+
+	return m.counters[counter], nil
+}
+
+func (m *MemoryQuote) Save(ctx context.Context, quote *types.Quote) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	revisions := m.revisions[quote.UUID]
+	if n := len(revisions); n > 0 && revisions[n-1].Number >= quote.Revision {
+		return types.ErrQuoteRevisionExists
+	}
+
+	m.quotes[quote.UUID] = *copyQuote(*quote)
+	m.revisions[quote.UUID] = append(revisions, types.QuoteRevision{
+		QuoteID:   quote.UUID,
+		Number:    quote.Revision,
+		CreatedAt: quote.UpdatedAt,
+		CreatedBy: quote.UpdatedBy,
+		Quote:     *copyQuote(*quote),
+	})
+
+	return nil
+}
+
+func (m *MemoryQuote) FindRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	revisions := make([]types.QuoteRevision, 0, len(m.revisions[quoteUUID]))
+	for _, revision := range m.revisions[quoteUUID] {
+		revision.Quote = *copyQuote(revision.Quote)
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+func (m *MemoryQuote) FindRevision(ctx context.Context, quoteUUID uuid.UUID, number int) (*types.QuoteRevision, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, revision := range m.revisions[quoteUUID] {
+		if revision.Number == number {
+			revision.Quote = *copyQuote(revision.Quote)
+			return &revision, nil
+		}
+	}
+
+	return nil, types.ErrQuoteRevisionNotFound
+}
+
+// ScanQuotes calls fn with a copy of every quote of the filter, ordered by the last change.
+// An error of fn stops the scan.
+func (m *MemoryQuote) ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error {
+	m.mu.RLock()
+	quotes := make([]*types.Quote, 0)
+	for _, quote := range m.quotes {
+		if filter.Matches(&quote) {
+			quotes = append(quotes, copyQuote(quote))
+		}
+	}
+	m.mu.RUnlock()
+
+	sortByUpdatedAt(quotes)
+	for _, quote := range quotes {
+		if err := fn(quote); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindQuotes returns copies of a page of the quotes of the query.
+func (m *MemoryQuote) FindQuotes(ctx context.Context, query types.QuoteQuery) (*types.QuotePage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	quotes := make([]*types.Quote, 0)
+	for _, quote := range m.quotes {
+		if query.Filter.Matches(&quote) {
+			quotes = append(quotes, copyQuote(quote))
+		}
+	}
+
+	return query.Page(quotes), nil
+}
+
+// sortByUpdatedAt orders the quotes by their last change, quotes changed at the same time by their ID.
+func sortByUpdatedAt(quotes []*types.Quote) {
+	slices.SortFunc(quotes, func(a, b *types.Quote) int {
+		if c := a.UpdatedAt.Compare(b.UpdatedAt); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.UUID.String(), b.UUID.String())
+	})
+}
+
+func copyQuote(quote types.Quote) *types.Quote {
+	quote.Changes = nil
+	if quote.Address != nil {
+		address := *quote.Address
+		quote.Address = &address
+	}
+	if quote.Payment != nil {
+		payment := *quote.Payment
+		quote.Payment = &payment
+	}
+	if quote.UpdatedBy != nil {
+		actor := *quote.UpdatedBy
+		quote.UpdatedBy = &actor
+	}
+	if quote.Acceptance != nil {
+		acceptance := *quote.Acceptance
+		quote.Acceptance = &acceptance
+	}
+	quote.Offers = slices.Clone(quote.Offers)
+	for i := range quote.Offers {
+		quote.Offers[i].Lines = slices.Clone(quote.Offers[i].Lines)
+	}
+	quote.Products = slices.Clone(quote.Products)
+	for i := range quote.Products {
+		if quote.Products[i].Override != nil {
+			override := *quote.Products[i].Override
+			quote.Products[i].Override = &override
+		}
+	}
+
+	return &quote
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryShare keeps quote shares and their accesses in process memory. It is meant for tests and local development.
+type MemoryShare struct {
+	mu       sync.RWMutex
+	shares   map[uuid.UUID]types.Share
+	accesses map[uuid.UUID][]types.ShareAccess
+}
+
+func NewMemoryShare() *MemoryShare {
+	return &MemoryShare{
+		shares:   make(map[uuid.UUID]types.Share),
+		accesses: make(map[uuid.UUID][]types.ShareAccess),
+	}
+}
+
+// FindShares returns the shares of the quote, oldest first.
+func (m *MemoryShare) FindShares(ctx context.Context, quoteUUID uuid.UUID) ([]*types.Share, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	shares := make([]*types.Share, 0)
+	for _, share := range m.shares {
+		if share.QuoteID == quoteUUID {
+			shares = append(shares, copyShare(share))
+		}
+	}
+	slices.SortFunc(shares, func(a, b *types.Share) int {
+		if c := a.CreatedAt.Compare(b.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UUID.String(), b.UUID.String())
+	})
+
+	return shares, nil
+}
+
+func (m *MemoryShare) FindShareByID(ctx context.Context, shareUUID uuid.UUID) (*types.Share, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	share, ok := m.shares[shareUUID]
+	if !ok {
+		return nil, types.ErrShareNotFound
+	}
+
+	return copyShare(share), nil
+}
+
+func (m *MemoryShare) SaveShare(ctx context.Context, share *types.Share) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.shares[share.UUID] = *copyShare(*share)
+
+	return nil
+}
+
+func (m *MemoryShare) RecordShareAccess(ctx context.Context, access types.ShareAccess) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.shares[access.ShareID]; !ok {
+		return types.ErrShareNotFound
+	}
+	m.accesses[access.ShareID] = append(m.accesses[access.ShareID], access)
+
+	return nil
+}
+
+// FindShareAccesses returns the accesses of the share, oldest first.
+func (m *MemoryShare) FindShareAccesses(ctx context.Context, shareUUID uuid.UUID) ([]types.ShareAccess, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	accesses := slices.Clone(m.accesses[shareUUID])
+	if accesses == nil {
+		accesses = make([]types.ShareAccess, 0)
+	}
+
+	return accesses, nil
+}
+
+func copyShare(share types.Share) *types.Share {
+	if share.CreatedBy != nil {
+		actor := *share.CreatedBy
+		share.CreatedBy = &actor
+	}
+
+	return &share
+}
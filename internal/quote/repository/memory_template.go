@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/google/uuid"
+)
+
+// MemoryTemplate keeps quote templates in process memory. It is meant for tests and local development.
+type MemoryTemplate struct {
+	mu        sync.RWMutex
+	templates map[uuid.UUID]types.Template
+}
+
+func NewMemoryTemplate() *MemoryTemplate {
+	return &MemoryTemplate{
+		templates: make(map[uuid.UUID]types.Template),
+	}
+}
+
+// FindTemplates returns every template ordered by its name.
+func (m *MemoryTemplate) FindTemplates(ctx context.Context) ([]*types.Template, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	templates := make([]*types.Template, 0, len(m.templates))
+	for _, template := range m.templates {
+		templates = append(templates, copyTemplate(template))
+	}
+	slices.SortFunc(templates, func(a, b *types.Template) int {
+		if c := strings.Compare(a.Name, b.Name); c != 0 {
+			return c
+		}
+		return strings.Compare(a.UUID.String(), b.UUID.String())
+	})
+
+	return templates, nil
+}
+
+func (m *MemoryTemplate) FindTemplateByID(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	template, ok := m.templates[templateUUID]
+	if !ok {
+		return nil, types.ErrTemplateNotFound
+	}
+
+	return copyTemplate(template), nil
+}
+
+func (m *MemoryTemplate) SaveTemplate(ctx context.Context, template *types.Template) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.templates[template.UUID] = *copyTemplate(*template)
+
+	return nil
+}
+
+func (m *MemoryTemplate) DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.templates[templateUUID]; !ok {
+		return types.ErrTemplateNotFound
+	}
+	delete(m.templates, templateUUID)
+
+	return nil
+}
+
+func copyTemplate(template types.Template) *types.Template {
+	template.Lines = slices.Clone(template.Lines)
+	if template.UpdatedBy != nil {
+		actor := *template.UpdatedBy
+		template.UpdatedBy = &actor
+	}
+
+	return &template
+}
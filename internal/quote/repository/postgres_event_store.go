@@ -0,0 +1,348 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const pgUniqueViolation = "23505"
+
+// PostgresEventStore keeps quote events in PostgreSQL, see migrations/0001_quote_event_store.sql,
+// migrations/0003_quote_stream_updated_at.sql, migrations/0004_quote_numbers.sql
+// and migrations/0005_quote_stream_created_at.sql.
+type PostgresEventStore struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresEventStore(pool *pgxpool.Pool) *PostgresEventStore {
+	return &PostgresEventStore{
+		pool: pool,
+	}
+}
+
+func (p *PostgresEventStore) Append(ctx context.Context, quoteUUID uuid.UUID, expectedVersion int, events []types.Event, index QuoteIndex) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresEventStore::Append : %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	var version int
+	err = tx.QueryRow(ctx, `SELECT version FROM quote_streams WHERE quote_id = $1 FOR UPDATE`, quoteUUID).Scan(&version)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("Repository::PostgresEventStore::Append : %w", err)
+	}
+	if version != expectedVersion {
+		return fmt.Errorf("Repository::PostgresEventStore::Append : %w", types.ErrQuoteVersionConflict)
+	}
+
+	batch := &pgx.Batch{}
+	for _, event := range events {
+		data, err := json.Marshal(event.Data)
+		if err != nil {
+			return fmt.Errorf("Repository::PostgresEventStore::Append : %w", err)
+		}
+
+		actor, err := json.Marshal(event.Actor)
+		if err != nil {
+			return fmt.Errorf("Repository::PostgresEventStore::Append : %w", err)
+		}
+
+		batch.Queue(
+			`INSERT INTO quote_events (quote_id, version, revision, type, data, actor, occurred_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)`,
+			quoteUUID, event.Version, event.Revision, string(event.Data.EventType()), data, actor, event.OccurredAt,
+		)
+	}
+
+	// quotes which were never activated are stored without activation time and are found last
+	var activatedAt *time.Time
+	if !index.ActivatedAt.IsZero() {
+		activatedAt = &index.ActivatedAt
+	}
+	batch.Queue(
+		`INSERT INTO quote_streams (quote_id, customer_id, status, version, updated_at, number, created_at, activated_at)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''), $7, $8)
+		ON CONFLICT (quote_id) DO UPDATE
+		SET status = EXCLUDED.status, version = EXCLUDED.version, updated_at = EXCLUDED.updated_at,
+		number = COALESCE(EXCLUDED.number, quote_streams.number), activated_at = EXCLUDED.activated_at`,
+		quoteUUID, index.CustomerID, string(index.Status), events[len(events)-1].Version, index.UpdatedAt,
+		index.Number, index.CreatedAt, activatedAt,
+	)
+
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == pgUniqueViolation {
+			return fmt.Errorf("Repository::PostgresEventStore::Append : %w", types.ErrQuoteVersionConflict)
+		}
+
+		return fmt.Errorf("Repository::PostgresEventStore::Append : %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("Repository::PostgresEventStore::Append : %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresEventStore) Load(ctx context.Context, quoteUUID uuid.UUID, afterVersion int) ([]types.Event, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`SELECT version, revision, type, data, actor, occurred_at
+		FROM quote_events
+		WHERE quote_id = $1 AND version > $2
+		ORDER BY version`,
+		quoteUUID, afterVersion,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresEventStore::Load : %w", err)
+	}
+	defer rows.Close()
+
+	events := make([]types.Event, 0)
+	for rows.Next() {
+		var (
+			event      types.Event
+			eventType  string
+			data       []byte
+			actor      []byte
+			occurredAt time.Time
+		)
+		if err := rows.Scan(&event.Version, &event.Revision, &eventType, &data, &actor, &occurredAt); err != nil {
+			return nil, fmt.Errorf("Repository::PostgresEventStore::Load : %w", err)
+		}
+
+		event.Data, err = types.DecodeEventData(types.EventType(eventType), data)
+		if err != nil {
+			return nil, fmt.Errorf("Repository::PostgresEventStore::Load : %w", err)
+		}
+
+		if err := json.Unmarshal(actor, &event.Actor); err != nil {
+			return nil, fmt.Errorf("Repository::PostgresEventStore::Load : %w", err)
+		}
+
+		event.QuoteID = quoteUUID
+		event.OccurredAt = occurredAt
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("Repository::PostgresEventStore::Load : %w", err)
+	}
+
+	return events, nil
+}
+
+func (p *PostgresEventStore) LoadSnapshot(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error) {
+	var data []byte
+	err := p.pool.QueryRow(ctx, `SELECT data FROM quote_snapshots WHERE quote_id = $1`, quoteUUID).Scan(&data)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("Repository::PostgresEventStore::LoadSnapshot : %w", err)
+	}
+
+	var quote types.Quote
+	if err := json.Unmarshal(data, &quote); err != nil {
+		return nil, fmt.Errorf("Repository::PostgresEventStore::LoadSnapshot : %w", err)
+	}
+
+	return &quote, nil
+}
+
+func (p *PostgresEventStore) SaveSnapshot(ctx context.Context, quote *types.Quote) error {
+	data, err := json.Marshal(quote)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresEventStore::SaveSnapshot : %w", err)
+	}
+
+	_, err = p.pool.Exec(
+		ctx,
+		`INSERT INTO quote_snapshots (quote_id, version, data)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (quote_id) DO UPDATE SET version = EXCLUDED.version, data = EXCLUDED.data
+		WHERE quote_snapshots.version < EXCLUDED.version`,
+		quote.UUID, quote.Version, data,
+	)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresEventStore::SaveSnapshot : %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresEventStore) FindQuoteID(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (uuid.UUID, error) {
+	var quoteUUID uuid.UUID
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT quote_id FROM quote_streams
+		WHERE customer_id = $1 AND status = $2
+		ORDER BY activated_at DESC NULLS LAST, updated_at DESC, quote_id
+		LIMIT 1`,
+		customerUUID, string(status),
+	).Scan(&quoteUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.UUID{}, types.ErrQuoteNotFound
+		}
+
+		return uuid.UUID{}, fmt.Errorf("Repository::PostgresEventStore::FindQuoteID : %w", err)
+	}
+
+	return quoteUUID, nil
+}
+
+func (p *PostgresEventStore) FindQuoteIDs(ctx context.Context, filter types.QuoteFilter) ([]uuid.UUID, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`SELECT quote_id FROM quote_streams
+		WHERE `+quoteFilterCondition+`
+		ORDER BY updated_at, quote_id`,
+		quoteFilterArgs(filter)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresEventStore::FindQuoteIDs : %w", err)
+	}
+
+	quoteUUIDs, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresEventStore::FindQuoteIDs : %w", err)
+	}
+
+	return quoteUUIDs, nil
+}
+
+// FindQuotePage selects one row more than the limit, so the cursor is only returned when another page follows.
+func (p *PostgresEventStore) FindQuotePage(ctx context.Context, query types.QuoteQuery) ([]uuid.UUID, *types.QuoteCursor, error) {
+	order := query.Order()
+	if !slices.Contains(types.QuoteSorts, order) {
+		return nil, nil, fmt.Errorf("Repository::PostgresEventStore::FindQuotePage : unsupported sort %q", order)
+	}
+
+	column, direction, comparison := order.Field(), "ASC", ">"
+	if order.Descending() {
+		direction, comparison = "DESC", "<"
+	}
+
+	var (
+		afterTime *time.Time
+		afterID   *uuid.UUID
+		limit     *int
+	)
+	if query.After != nil {
+		afterTime, afterID = &query.After.Time, &query.After.ID
+	}
+	if query.Limit > 0 {
+		size := query.Limit + 1
+		limit = &size
+	}
+
+	rows, err := p.pool.Query(
+		ctx,
+		`SELECT quote_id, `+column+` FROM quote_streams
+		WHERE `+quoteFilterCondition+`
+		AND ($5::timestamptz IS NULL OR (`+column+`, quote_id) `+comparison+` ($5, $6::uuid))
+		ORDER BY `+column+` `+direction+`, quote_id `+direction+`
+		LIMIT $7`,
+		append(quoteFilterArgs(query.Filter), afterTime, afterID, limit)...,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository::PostgresEventStore::FindQuotePage : %w", err)
+	}
+
+	cursors, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (types.QuoteCursor, error) {
+		var cursor types.QuoteCursor
+		err := row.Scan(&cursor.ID, &cursor.Time)
+		return cursor, err
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("Repository::PostgresEventStore::FindQuotePage : %w", err)
+	}
+
+	var next *types.QuoteCursor
+	if query.Limit > 0 && len(cursors) > query.Limit {
+		cursors = cursors[:query.Limit]
+		next = &cursors[query.Limit-1]
+	}
+
+	quoteUUIDs := make([]uuid.UUID, 0, len(cursors))
+	for _, cursor := range cursors {
+		quoteUUIDs = append(quoteUUIDs, cursor.ID)
+	}
+
+	return quoteUUIDs, next, nil
+}
+
+func (p *PostgresEventStore) FindQuoteIDByNumber(ctx context.Context, number string) (uuid.UUID, error) {
+	var quoteUUID uuid.UUID
+	err := p.pool.QueryRow(ctx, `SELECT quote_id FROM quote_streams WHERE number = $1`, number).Scan(&quoteUUID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return uuid.UUID{}, types.ErrQuoteNotFound
+		}
+
+		return uuid.UUID{}, fmt.Errorf("Repository::PostgresEventStore::FindQuoteIDByNumber : %w", err)
+	}
+
+	return quoteUUID, nil
+}
+
+// NextNumber increments the counter row of the tenant and year. A sequence object per tenant and year
+// would need DDL at runtime, the upsert locks the row instead, so concurrent callers get distinct numbers.
+func (p *PostgresEventStore) NextNumber(ctx context.Context, tenant string, year int) (int, error) {
+	var sequence int
+	err := p.pool.QueryRow(
+		ctx,
+		`INSERT INTO quote_number_counters (tenant, year, value)
+		VALUES ($1, $2, 1)
+		ON CONFLICT (tenant, year) DO UPDATE SET value = quote_number_counters.value + 1
+		RETURNING value`,
+		tenant, year,
+	).Scan(&sequence)
+	if err != nil {
+		return 0, fmt.Errorf("Repository::PostgresEventStore::NextNumber : %w", err)
+	}
+
+	return sequence, nil
+}
+
+// quoteFilterCondition matches the rows of a quote filter, the arguments $1 to $4 are set by quoteFilterArgs.
+const quoteFilterCondition = `($1::uuid IS NULL OR customer_id = $1)
+		AND ($2::text[] IS NULL OR status = ANY($2))
+		AND ($3::timestamptz IS NULL OR updated_at >= $3)
+		AND ($4::timestamptz IS NULL OR updated_at < $4)`
+
+// quoteFilterArgs passes the unset bounds of the filter as NULL.
+func quoteFilterArgs(filter types.QuoteFilter) []any {
+	var (
+		customerUUID           *uuid.UUID
+		statuses               []string
+		updatedFrom, updatedTo *time.Time
+	)
+	if filter.CustomerID != uuid.Nil {
+		customerUUID = &filter.CustomerID
+	}
+	for _, status := range filter.Statuses {
+		statuses = append(statuses, string(status))
+	}
+	if !filter.UpdatedFrom.IsZero() {
+		updatedFrom = &filter.UpdatedFrom
+	}
+	if !filter.UpdatedTo.IsZero() {
+		updatedTo = &filter.UpdatedTo
+	}
+
+	return []any{customerUUID, statuses, updatedFrom, updatedTo}
+}
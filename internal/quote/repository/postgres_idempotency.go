@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresIdempotency keeps idempotency records in PostgreSQL, see migrations/0002_idempotency_keys.sql.
+type PostgresIdempotency struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresIdempotency(pool *pgxpool.Pool) *PostgresIdempotency {
+	return &PostgresIdempotency{
+		pool: pool,
+	}
+}
+
+// Reserve stores the record unless a not expired record with the same key exists, which is returned instead.
+// An expired record is replaced in the same statement, so concurrent retries reserve the key only once.
+func (p *PostgresIdempotency) Reserve(ctx context.Context, record *types.IdempotencyRecord) (*types.IdempotencyRecord, error) {
+	tag, err := p.pool.Exec(
+		ctx,
+		`INSERT INTO idempotency_keys (key, fingerprint, completed, created_at, expires_at)
+		VALUES ($1, $2, false, $3, $4)
+		ON CONFLICT (key) DO UPDATE
+		SET fingerprint = EXCLUDED.fingerprint, completed = false, status_code = NULL, header = NULL, body = NULL,
+			created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= EXCLUDED.created_at`,
+		record.Key, record.Fingerprint, record.CreatedAt, record.ExpiresAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresIdempotency::Reserve : %w", err)
+	}
+	if tag.RowsAffected() == 1 {
+		return nil, nil
+	}
+
+	existing, err := p.find(ctx, record.Key)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresIdempotency::Reserve : %w", err)
+	}
+
+	return existing, nil
+}
+
+func (p *PostgresIdempotency) Complete(ctx context.Context, record *types.IdempotencyRecord) error {
+	header, err := json.Marshal(record.Header)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresIdempotency::Complete : %w", err)
+	}
+
+	_, err = p.pool.Exec(
+		ctx,
+		`UPDATE idempotency_keys SET completed = true, status_code = $2, header = $3, body = $4 WHERE key = $1`,
+		record.Key, record.StatusCode, header, record.Body,
+	)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresIdempotency::Complete : %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresIdempotency) Release(ctx context.Context, key string) error {
+	_, err := p.pool.Exec(ctx, `DELETE FROM idempotency_keys WHERE key = $1`, key)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresIdempotency::Release : %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresIdempotency) find(ctx context.Context, key string) (*types.IdempotencyRecord, error) {
+	var (
+		record     = types.IdempotencyRecord{Key: key}
+		statusCode *int
+		header     []byte
+	)
+	err := p.pool.QueryRow(
+		ctx,
+		`SELECT fingerprint, completed, status_code, header, body, created_at, expires_at
+		FROM idempotency_keys WHERE key = $1`,
+		key,
+	).Scan(&record.Fingerprint, &record.Completed, &statusCode, &header, &record.Body, &record.CreatedAt, &record.ExpiresAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// the first request failed and released the key in the meantime, the client may retry
+		return nil, types.ErrIdempotencyKeyInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode != nil {
+		record.StatusCode = *statusCode
+	}
+	if header != nil {
+		if err := json.Unmarshal(header, &record.Header); err != nil {
+			return nil, err
+		}
+	}
+
+	return &record, nil
+}
@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresShare keeps quote shares and their accesses in PostgreSQL, see migrations/0008_quote_shares.sql.
+type PostgresShare struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresShare(pool *pgxpool.Pool) *PostgresShare {
+	return &PostgresShare{
+		pool: pool,
+	}
+}
+
+const shareColumns = `id, quote_id, customer_id, revision, created_at, created_by, expires_at, revoked_at`
+
+// FindShares returns the shares of the quote, oldest first.
+func (p *PostgresShare) FindShares(ctx context.Context, quoteUUID uuid.UUID) ([]*types.Share, error) {
+	rows, err := p.pool.Query(ctx, `SELECT `+shareColumns+` FROM quote_shares WHERE quote_id = $1 ORDER BY created_at, id`, quoteUUID)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresShare::FindShares : %w", err)
+	}
+
+	shares, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*types.Share, error) {
+		return scanShare(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresShare::FindShares : %w", err)
+	}
+
+	return shares, nil
+}
+
+func (p *PostgresShare) FindShareByID(ctx context.Context, shareUUID uuid.UUID) (*types.Share, error) {
+	share, err := scanShare(p.pool.QueryRow(ctx, `SELECT `+shareColumns+` FROM quote_shares WHERE id = $1`, shareUUID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("Repository::PostgresShare::FindShareByID : %w", types.ErrShareNotFound)
+		}
+
+		return nil, fmt.Errorf("Repository::PostgresShare::FindShareByID : %w", err)
+	}
+
+	return share, nil
+}
+
+func (p *PostgresShare) SaveShare(ctx context.Context, share *types.Share) error {
+	createdBy, err := json.Marshal(share.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresShare::SaveShare : %w", err)
+	}
+
+	var revokedAt *time.Time
+	if share.Revoked() {
+		revokedAt = &share.RevokedAt
+	}
+
+	_, err = p.pool.Exec(
+		ctx,
+		`INSERT INTO quote_shares (`+shareColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT (id) DO UPDATE SET revoked_at = EXCLUDED.revoked_at`,
+		share.UUID, share.QuoteID, share.CustomerID, share.Revision, share.CreatedAt, createdBy, share.ExpiresAt, revokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresShare::SaveShare : %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresShare) RecordShareAccess(ctx context.Context, access types.ShareAccess) error {
+	_, err := p.pool.Exec(
+		ctx,
+		`INSERT INTO quote_share_accesses (share_id, accessed_at, ip, user_agent) VALUES ($1, $2, $3, $4)`,
+		access.ShareID, access.AccessedAt, access.IP, access.UserAgent,
+	)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresShare::RecordShareAccess : %w", err)
+	}
+
+	return nil
+}
+
+// FindShareAccesses returns the accesses of the share, oldest first.
+func (p *PostgresShare) FindShareAccesses(ctx context.Context, shareUUID uuid.UUID) ([]types.ShareAccess, error) {
+	rows, err := p.pool.Query(
+		ctx,
+		`SELECT share_id, accessed_at, ip, user_agent FROM quote_share_accesses WHERE share_id = $1 ORDER BY accessed_at`,
+		shareUUID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresShare::FindShareAccesses : %w", err)
+	}
+
+	accesses, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (types.ShareAccess, error) {
+		var access types.ShareAccess
+		err := row.Scan(&access.ShareID, &access.AccessedAt, &access.IP, &access.UserAgent)
+		return access, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresShare::FindShareAccesses : %w", err)
+	}
+
+	return accesses, nil
+}
+
+func scanShare(row pgx.Row) (*types.Share, error) {
+	var (
+		share     types.Share
+		createdBy []byte
+		revokedAt *time.Time
+	)
+	err := row.Scan(&share.UUID, &share.QuoteID, &share.CustomerID, &share.Revision, &share.CreatedAt, &createdBy, &share.ExpiresAt, &revokedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(createdBy, &share.CreatedBy); err != nil {
+		return nil, err
+	}
+	if revokedAt != nil {
+		share.RevokedAt = *revokedAt
+	}
+
+	return &share, nil
+}
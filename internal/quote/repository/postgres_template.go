@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"app/internal/quote/types"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresTemplate keeps quote templates in PostgreSQL, see migrations/0007_quote_templates.sql.
+type PostgresTemplate struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresTemplate(pool *pgxpool.Pool) *PostgresTemplate {
+	return &PostgresTemplate{
+		pool: pool,
+	}
+}
+
+const templateColumns = `id, name, description, lines, created_at, updated_at, updated_by`
+
+// FindTemplates returns every template ordered by its name.
+func (p *PostgresTemplate) FindTemplates(ctx context.Context) ([]*types.Template, error) {
+	rows, err := p.pool.Query(ctx, `SELECT `+templateColumns+` FROM quote_templates ORDER BY name, id`)
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresTemplate::FindTemplates : %w", err)
+	}
+
+	templates, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*types.Template, error) {
+		return scanTemplate(row)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Repository::PostgresTemplate::FindTemplates : %w", err)
+	}
+
+	return templates, nil
+}
+
+func (p *PostgresTemplate) FindTemplateByID(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error) {
+	template, err := scanTemplate(p.pool.QueryRow(ctx, `SELECT `+templateColumns+` FROM quote_templates WHERE id = $1`, templateUUID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("Repository::PostgresTemplate::FindTemplateByID : %w", types.ErrTemplateNotFound)
+		}
+
+		return nil, fmt.Errorf("Repository::PostgresTemplate::FindTemplateByID : %w", err)
+	}
+
+	return template, nil
+}
+
+func (p *PostgresTemplate) SaveTemplate(ctx context.Context, template *types.Template) error {
+	lines, err := json.Marshal(template.Lines)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresTemplate::SaveTemplate : %w", err)
+	}
+
+	updatedBy, err := json.Marshal(template.UpdatedBy)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresTemplate::SaveTemplate : %w", err)
+	}
+
+	_, err = p.pool.Exec(
+		ctx,
+		`INSERT INTO quote_templates (`+templateColumns+`)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (id) DO UPDATE
+		SET name = EXCLUDED.name, description = EXCLUDED.description, lines = EXCLUDED.lines,
+		updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by`,
+		template.UUID, template.Name, template.Description, lines, template.CreatedAt, template.UpdatedAt, updatedBy,
+	)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresTemplate::SaveTemplate : %w", err)
+	}
+
+	return nil
+}
+
+func (p *PostgresTemplate) DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error {
+	tag, err := p.pool.Exec(ctx, `DELETE FROM quote_templates WHERE id = $1`, templateUUID)
+	if err != nil {
+		return fmt.Errorf("Repository::PostgresTemplate::DeleteTemplate : %w", err)
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("Repository::PostgresTemplate::DeleteTemplate : %w", types.ErrTemplateNotFound)
+	}
+
+	return nil
+}
+
+func scanTemplate(row pgx.Row) (*types.Template, error) {
+	var (
+		template  types.Template
+		lines     []byte
+		updatedBy []byte
+	)
+	err := row.Scan(&template.UUID, &template.Name, &template.Description, &lines, &template.CreatedAt, &template.UpdatedAt, &updatedBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(lines, &template.Lines); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(updatedBy, &template.UpdatedBy); err != nil {
+		return nil, err
+	}
+
+	return &template, nil
+}
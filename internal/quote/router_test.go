@@ -0,0 +1,76 @@
+package quote_test
+
+import (
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+
+	"app/internal/config"
+	"app/internal/quote"
+)
+
+const openAPISpecPath = "../../Openapi.yaml"
+
+type openAPISpec struct {
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths map[string]map[string]interface{} `yaml:"paths"`
+}
+
+func loadOpenAPIOperations(t *testing.T) (string, []string) {
+	data, err := os.ReadFile(openAPISpecPath)
+	assert.NoError(t, err)
+
+	var spec openAPISpec
+	assert.NoError(t, yaml.Unmarshal(data, &spec))
+
+	operations := make([]string, 0)
+	for path, methods := range spec.Paths {
+		for method := range methods {
+			operations = append(operations, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(operations)
+
+	assert.NotEmpty(t, spec.Servers)
+	return spec.Servers[0].URL, operations
+}
+
+// The router must serve exactly the operations of the OpenAPI contract under its server prefix.
+func TestRouterMatchesOpenAPI(t *testing.T) {
+	// arrange
+	serverURL, expected := loadOpenAPIOperations(t)
+	storage := config.Storage{
+		Persistence:      config.PersistenceSnapshot,
+		IdempotencyStore: config.IdempotencyStoreMemory,
+		TemplateStore:    config.TemplateStoreMemory,
+		ShareStore:       config.ShareStoreMemory,
+	}
+	deps, err := quote.NewDependencies(storage, nil)
+	assert.NoError(t, err)
+	router := quote.RouterAPIInitializer(config.Config{Storage: storage}, deps)
+	assert.NotNil(t, router)
+	assert.True(t, strings.HasSuffix(serverURL, quote.APIVersionPrefix))
+
+	// act
+	actual := make([]string, 0)
+	err = chi.Walk(router, func(method string, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		path, found := strings.CutPrefix(route, quote.APIVersionPrefix)
+		if found {
+			actual = append(actual, method+" "+path)
+		}
+		return nil
+	})
+	sort.Strings(actual)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
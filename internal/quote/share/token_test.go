@@ -0,0 +1,73 @@
+package share_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"app/internal/quote/share"
+)
+
+func TestSignerRoundTrip(t *testing.T) {
+	// arrange
+	signer := share.NewSigner(share.Config{Key: "test-secret"})
+	claims := share.Claims{
+		ShareID:   uuid.New(),
+		QuoteID:   uuid.New(),
+		Revision:  3,
+		ExpiresAt: time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	// act
+	token, err := signer.Sign(claims)
+	assert.NoError(t, err)
+	verified, err := signer.Verify(token)
+
+	// assert
+	assert.NoError(t, err)
+	assert.Equal(t, claims, *verified)
+	assert.NotContains(t, token, "=")
+}
+
+func TestSignerVerifyInvalid(t *testing.T) {
+	signer := share.NewSigner(share.Config{Key: "test-secret"})
+	token, err := signer.Sign(share.Claims{ShareID: uuid.New(), QuoteID: uuid.New(), Revision: 1})
+	assert.NoError(t, err)
+
+	payload, signature, _ := strings.Cut(token, ".")
+	forged, err := share.NewSigner(share.Config{Key: "other-secret"}).Sign(share.Claims{ShareID: uuid.New(), QuoteID: uuid.New(), Revision: 2})
+	assert.NoError(t, err)
+	forgedPayload, _, _ := strings.Cut(forged, ".")
+
+	for name, token := range map[string]string{
+		"empty":             "",
+		"without signature": payload,
+		"other key":         forged,
+		"swapped payload":   forgedPayload + "." + signature,
+		"broken signature":  payload + ".%%%",
+	} {
+		t.Run(name, func(t *testing.T) {
+			// act
+			claims, err := signer.Verify(token)
+
+			// assert
+			assert.ErrorIs(t, err, share.ErrTokenInvalid)
+			assert.Nil(t, claims)
+		})
+	}
+}
+
+func TestSignerNotConfigured(t *testing.T) {
+	// arrange
+	signer := share.NewSigner(share.Config{})
+
+	// act
+	token, err := signer.Sign(share.Claims{ShareID: uuid.New()})
+
+	// assert
+	assert.ErrorIs(t, err, share.ErrSignerNotConfigured)
+	assert.Empty(t, token)
+}
@@ -0,0 +1,124 @@
+package quote
+
+import (
+	"app/internal/config"
+	"app/internal/quote/repository"
+	"app/internal/quote/types"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type quoteRepository interface {
+	FindByCustomerAndStatus(ctx context.Context, customerUUID uuid.UUID, status types.QuoteStatus) (*types.Quote, error)
+	FindByID(ctx context.Context, quoteUUID uuid.UUID) (*types.Quote, error)
+	FindByNumber(ctx context.Context, number string) (*types.Quote, error)
+	NextQuoteNumber(ctx context.Context, tenant string, year int) (int, error)
+	ScanQuotes(ctx context.Context, filter types.QuoteFilter, fn func(quote *types.Quote) error) error
+	FindQuotes(ctx context.Context, query types.QuoteQuery) (*types.QuotePage, error)
+	Save(ctx context.Context, quote *types.Quote) error
+	FindRevisions(ctx context.Context, quoteUUID uuid.UUID) ([]types.QuoteRevision, error)
+	FindRevision(ctx context.Context, quoteUUID uuid.UUID, number int) (*types.QuoteRevision, error)
+}
+
+type idempotencyStore interface {
+	Reserve(ctx context.Context, record *types.IdempotencyRecord) (*types.IdempotencyRecord, error)
+	Complete(ctx context.Context, record *types.IdempotencyRecord) error
+	Release(ctx context.Context, key string) error
+}
+
+type templateStore interface {
+	FindTemplates(ctx context.Context) ([]*types.Template, error)
+	FindTemplateByID(ctx context.Context, templateUUID uuid.UUID) (*types.Template, error)
+	SaveTemplate(ctx context.Context, template *types.Template) error
+	DeleteTemplate(ctx context.Context, templateUUID uuid.UUID) error
+}
+
+type shareStore interface {
+	FindShares(ctx context.Context, quoteUUID uuid.UUID) ([]*types.Share, error)
+	FindShareByID(ctx context.Context, shareUUID uuid.UUID) (*types.Share, error)
+	SaveShare(ctx context.Context, share *types.Share) error
+	RecordShareAccess(ctx context.Context, access types.ShareAccess) error
+	FindShareAccesses(ctx context.Context, shareUUID uuid.UUID) ([]types.ShareAccess, error)
+}
+
+var (
+	errUnknownStorage = errors.New("unknown storage")
+)
+
+// NewDatabasePool connects to DATABASE_URL when any store is kept in PostgreSQL, otherwise it returns nil.
+// The stores share the pool, the caller closes it on shutdown.
+func NewDatabasePool(ctx context.Context, storage config.Storage) (*pgxpool.Pool, error) {
+	usesDatabase := storage.Persistence == config.PersistenceEvents && storage.EventStore == config.EventStorePostgres ||
+		storage.IdempotencyStore == config.IdempotencyStorePostgres ||
+		storage.TemplateStore == config.TemplateStorePostgres ||
+		storage.ShareStore == config.ShareStorePostgres
+	if !usesDatabase {
+		return nil, nil
+	}
+
+	pool, err := pgxpool.New(ctx, storage.DatabaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("NewDatabasePool : %w", err)
+	}
+
+	return pool, nil
+}
+
+// newQuoteRepository selects the quote persistence model configured for the application.
+func newQuoteRepository(storage config.Storage, pool *pgxpool.Pool) (quoteRepository, error) {
+	switch storage.Persistence {
+	case config.PersistenceSnapshot:
+		return repository.NewDynamoQuote(), nil
+	case config.PersistenceEvents:
+		switch storage.EventStore {
+		case config.EventStoreMemory:
+			return repository.NewEventSourcedQuote(repository.NewMemoryEventStore(), storage.SnapshotInterval), nil
+		case config.EventStorePostgres:
+			return repository.NewEventSourcedQuote(repository.NewPostgresEventStore(pool), storage.SnapshotInterval), nil
+		}
+
+		return nil, fmt.Errorf("newQuoteRepository : %w: event store %q", errUnknownStorage, storage.EventStore)
+	}
+
+	return nil, fmt.Errorf("newQuoteRepository : %w: persistence %q", errUnknownStorage, storage.Persistence)
+}
+
+// newIdempotencyStore selects where the responses of idempotent requests are kept.
+func newIdempotencyStore(storage config.Storage, pool *pgxpool.Pool) (idempotencyStore, error) {
+	switch storage.IdempotencyStore {
+	case config.IdempotencyStoreMemory:
+		return repository.NewMemoryIdempotency(), nil
+	case config.IdempotencyStorePostgres:
+		return repository.NewPostgresIdempotency(pool), nil
+	}
+
+	return nil, fmt.Errorf("newIdempotencyStore : %w: idempotency store %q", errUnknownStorage, storage.IdempotencyStore)
+}
+
+// newTemplateStore selects where the quote templates are kept.
+func newTemplateStore(storage config.Storage, pool *pgxpool.Pool) (templateStore, error) {
+	switch storage.TemplateStore {
+	case config.TemplateStoreMemory:
+		return repository.NewMemoryTemplate(), nil
+	case config.TemplateStorePostgres:
+		return repository.NewPostgresTemplate(pool), nil
+	}
+
+	return nil, fmt.Errorf("newTemplateStore : %w: template store %q", errUnknownStorage, storage.TemplateStore)
+}
+
+// newShareStore selects where the share links of quotes and their accesses are kept.
+func newShareStore(storage config.Storage, pool *pgxpool.Pool) (shareStore, error) {
+	switch storage.ShareStore {
+	case config.ShareStoreMemory:
+		return repository.NewMemoryShare(), nil
+	case config.ShareStorePostgres:
+		return repository.NewPostgresShare(pool), nil
+	}
+
+	return nil, fmt.Errorf("newShareStore : %w: share store %q", errUnknownStorage, storage.ShareStore)
+}
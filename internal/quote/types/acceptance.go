@@ -0,0 +1,56 @@
+package types
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Acceptance records the customer's explicit acceptance of the terms for a revision of the quote, like an
+// electronic signature. RevisionHash is the ContentHash of the accepted revision.
+type Acceptance struct {
+	Name         string
+	Email        string
+	AcceptedAt   time.Time
+	IP           string
+	UserAgent    string
+	Revision     int
+	RevisionHash string
+	TermsVersion string
+}
+
+// quoteContent is what the customer agrees to when accepting a quote, bookkeeping like the version or
+// the time of the last change is left out.
+type quoteContent struct {
+	UUID        uuid.UUID `json:"id"`
+	Number      string    `json:"number"`
+	CustomerID  uuid.UUID `json:"customer_id"`
+	Address     *Address  `json:"address"`
+	Payment     *Payment  `json:"payment"`
+	Products    []Product `json:"products"`
+	Amount      float64   `json:"amount"`
+	TaxAmount   float64   `json:"tax_amount"`
+	TotalAmount float64   `json:"total_amount"`
+}
+
+// ContentHash returns the SHA-256 of the quote content as hex, it changes with every line, price, address or
+// payment change but not with a change of the status.
+func (q *Quote) ContentHash() string {
+	data, _ := json.Marshal(quoteContent{
+		UUID:        q.UUID,
+		Number:      q.Number,
+		CustomerID:  q.CustomerID,
+		Address:     q.Address,
+		Payment:     q.Payment,
+		Products:    q.Products,
+		Amount:      q.Amount,
+		TaxAmount:   q.TaxAmount,
+		TotalAmount: q.TotalAmount,
+	})
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:])
+}
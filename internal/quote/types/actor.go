@@ -0,0 +1,43 @@
+package types
+
+import "context"
+
+type ActorType string
+
+const (
+	ActorTypeCustomer ActorType = "customer"
+	ActorTypeAgent    ActorType = "agent"
+	ActorTypeStaff    ActorType = "staff"
+)
+
+type Actor struct {
+	Type ActorType
+	ID   string
+}
+
+type (
+	actorCtx              struct{}
+	discountPermissionCtx struct{}
+)
+
+// WithActor returns a copy of the context carrying the actor who performs the change.
+func WithActor(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, actorCtx{}, actor)
+}
+
+// ActorFromContext returns the actor stored by WithActor.
+func ActorFromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(actorCtx{}).(Actor)
+	return actor, ok
+}
+
+// WithDiscountPermission returns a copy of the context whose actor may grant discounts.
+func WithDiscountPermission(ctx context.Context) context.Context {
+	return context.WithValue(ctx, discountPermissionCtx{}, true)
+}
+
+// HasDiscountPermission reports whether the context was marked by WithDiscountPermission.
+func HasDiscountPermission(ctx context.Context) bool {
+	granted, _ := ctx.Value(discountPermissionCtx{}).(bool)
+	return granted
+}
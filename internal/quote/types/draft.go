@@ -0,0 +1,35 @@
+package types
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type draftCtx struct{}
+
+// WithDraftID returns a copy of the context scoping the draft operations to the draft of the ID
+// instead of the active draft of the customer.
+func WithDraftID(ctx context.Context, quoteUUID uuid.UUID) context.Context {
+	return context.WithValue(ctx, draftCtx{}, quoteUUID)
+}
+
+// DraftIDFromContext returns the draft ID stored by WithDraftID.
+func DraftIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	quoteUUID, ok := ctx.Value(draftCtx{}).(uuid.UUID)
+	return quoteUUID, ok
+}
+
+// CompareActivation orders the drafts of a customer with the active draft first: the most recently activated,
+// drafts activated at the same time, e.g. never, by the most recent change and then by their ID.
+func CompareActivation(a, b *Quote) int {
+	if c := b.ActivatedAt.Compare(a.ActivatedAt); c != 0 {
+		return c
+	}
+	if c := b.UpdatedAt.Compare(a.UpdatedAt); c != 0 {
+		return c
+	}
+
+	return strings.Compare(a.UUID.String(), b.UUID.String())
+}
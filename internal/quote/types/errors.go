@@ -6,4 +6,26 @@ var (
 	ErrQuoteNotFound        = errors.New("quote not found")
 	ErrQuoteProductNotFound = errors.New("quote product not found")
 	ErrQuoteUnchangeable    = errors.New("quote can not be changed")
+	ErrQuoteNoPendingPrice  = errors.New("quote product has no pending price override")
+	ErrQuoteApprovalPending = errors.New("quote has pending approvals")
+	ErrQuoteModified        = errors.New("quote was modified since it was read")
+
+	ErrQuoteAcceptanceRequired  = errors.New("quote must be accepted before it is processed")
+	ErrQuoteAcceptanceForbidden = errors.New("quote can only be accepted by the customer")
+
+	ErrOfferNotFound = errors.New("quote offer not found")
+	ErrOfferNotOpen  = errors.New("quote offer is not open")
+	ErrOfferPending  = errors.New("quote offer waits for an answer of the other side")
+
+	ErrOfferDiscountForbidden = errors.New("quote offer discount is not granted to the actor")
+
+	ErrQuoteRevisionNotFound = errors.New("quote revision not found")
+	ErrQuoteRevisionExists   = errors.New("quote revision already exists")
+	ErrQuoteVersionConflict  = errors.New("quote was changed concurrently")
+
+	ErrTemplateNotFound = errors.New("quote template not found")
+
+	ErrShareNotFound = errors.New("quote share not found")
+	ErrShareExpired  = errors.New("quote share expired")
+	ErrShareRevoked  = errors.New("quote share was revoked")
 )
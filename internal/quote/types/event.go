@@ -0,0 +1,428 @@
+package types
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type EventType string
+
+const (
+	EventQuoteCreated           EventType = "quote_created"
+	EventQuoteNumbered          EventType = "quote_numbered"
+	EventDraftNamed             EventType = "draft_named"
+	EventDraftActivated         EventType = "draft_activated"
+	EventProductAdded           EventType = "product_added"
+	EventProductQuantityChanged EventType = "product_quantity_changed"
+	EventProductRemoved         EventType = "product_removed"
+	EventProductDiscountApplied EventType = "product_discount_applied"
+	EventProductPriceOverridden EventType = "product_price_overridden"
+	EventPriceOverrideDecided   EventType = "price_override_decided"
+	EventAddressSaved           EventType = "address_saved"
+	EventPaymentSaved           EventType = "payment_saved"
+	EventQuoteRepriced          EventType = "quote_repriced"
+	EventStatusChanged          EventType = "status_changed"
+	EventQuoteAccepted          EventType = "quote_accepted"
+	EventOfferProposed          EventType = "offer_proposed"
+	EventOfferAccepted          EventType = "offer_accepted"
+	EventOfferLineDecided       EventType = "offer_line_decided"
+)
+
+var (
+	ErrEventTypeUnknown = errors.New("event type is unknown")
+)
+
+// Event is a fact about a quote. Folding all events of a quote in version order rebuilds its state.
+type Event struct {
+	QuoteID    uuid.UUID
+	Version    int
+	Revision   int
+	OccurredAt time.Time
+	Actor      *Actor
+	Data       EventData
+}
+
+// EventData is the payload of an event, it knows how to change the quote state.
+type EventData interface {
+	EventType() EventType
+	apply(quote *Quote)
+}
+
+type (
+	QuoteCreated struct {
+		CustomerID uuid.UUID `json:"customer_id"`
+		CreatedAt  time.Time `json:"created_at"`
+	}
+
+	QuoteNumbered struct {
+		Number string `json:"number"`
+	}
+
+	DraftNamed struct {
+		Name string `json:"name"`
+	}
+
+	DraftActivated struct {
+		ActivatedAt time.Time `json:"activated_at"`
+	}
+
+	ProductAdded struct {
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+	}
+
+	ProductQuantityChanged struct {
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+	}
+
+	ProductRemoved struct {
+		ProductID uuid.UUID `json:"product_id"`
+	}
+
+	ProductDiscountApplied struct {
+		ProductID uuid.UUID `json:"product_id"`
+		Discount  float64   `json:"discount"`
+	}
+
+	ProductPriceOverridden struct {
+		ProductID uuid.UUID     `json:"product_id"`
+		Override  PriceOverride `json:"override"`
+	}
+
+	PriceOverrideDecided struct {
+		ProductID uuid.UUID      `json:"product_id"`
+		Status    ApprovalStatus `json:"status"`
+		DecidedBy *Actor         `json:"decided_by"`
+		DecidedAt time.Time      `json:"decided_at"`
+	}
+
+	AddressSaved struct {
+		Address *Address `json:"address"`
+	}
+
+	PaymentSaved struct {
+		Payment *Payment `json:"payment"`
+	}
+
+	QuoteRepriced struct {
+		Lines       []LinePrice `json:"lines"`
+		Amount      float64     `json:"amount"`
+		TaxAmount   float64     `json:"tax_amount"`
+		TotalAmount float64     `json:"total_amount"`
+	}
+
+	LinePrice struct {
+		ProductID   uuid.UUID `json:"product_id"`
+		Amount      float64   `json:"amount"`
+		TaxAmount   float64   `json:"tax_amount"`
+		TotalAmount float64   `json:"total_amount"`
+	}
+
+	StatusChanged struct {
+		Status QuoteStatus `json:"status"`
+	}
+
+	QuoteAccepted struct {
+		Acceptance Acceptance `json:"acceptance"`
+	}
+
+	OfferProposed struct {
+		Offer Offer `json:"offer"`
+	}
+
+	OfferAccepted struct {
+		Number     int       `json:"number"`
+		AcceptedBy *Actor    `json:"accepted_by"`
+		AcceptedAt time.Time `json:"accepted_at"`
+		// Pending are the products whose agreed price waits for the approval of a manager.
+		Pending []uuid.UUID `json:"pending,omitempty"`
+	}
+
+	OfferLineDecided struct {
+		Number    int            `json:"number"`
+		ProductID uuid.UUID      `json:"product_id"`
+		Status    ApprovalStatus `json:"status"`
+		DecidedBy *Actor         `json:"decided_by"`
+		DecidedAt time.Time      `json:"decided_at"`
+	}
+)
+
+// Record applies a new event to the quote and keeps it as an uncommitted change.
+func (q *Quote) Record(event Event) {
+	event.QuoteID = q.UUID
+	event.Version = q.Version + 1
+	event.Revision = q.Revision
+
+	q.Apply(event)
+	q.Changes = append(q.Changes, event)
+}
+
+// Apply changes the quote state by an already committed or a new event.
+func (q *Quote) Apply(event Event) {
+	event.Data.apply(q)
+
+	q.UUID = event.QuoteID
+	q.Version = event.Version
+	q.Revision = event.Revision
+	q.UpdatedAt = event.OccurredAt
+	q.UpdatedBy = event.Actor
+}
+
+// FoldQuote rebuilds a quote from its events, starting from the snapshot when it is given.
+func FoldQuote(snapshot *Quote, events []Event) *Quote {
+	quote := &Quote{}
+	if snapshot != nil {
+		quote = snapshot
+	}
+
+	for _, event := range events {
+		quote.Apply(event)
+	}
+
+	return quote
+}
+
+func (e QuoteCreated) EventType() EventType { return EventQuoteCreated }
+
+func (e QuoteCreated) apply(quote *Quote) {
+	quote.CustomerID = e.CustomerID
+	quote.CreatedAt = e.CreatedAt
+	quote.Status = QuoteStatusDraft
+}
+
+func (e QuoteNumbered) EventType() EventType { return EventQuoteNumbered }
+
+func (e QuoteNumbered) apply(quote *Quote) {
+	quote.Number = e.Number
+}
+
+func (e DraftNamed) EventType() EventType { return EventDraftNamed }
+
+func (e DraftNamed) apply(quote *Quote) {
+	quote.Name = e.Name
+}
+
+func (e DraftActivated) EventType() EventType { return EventDraftActivated }
+
+func (e DraftActivated) apply(quote *Quote) {
+	quote.ActivatedAt = e.ActivatedAt
+}
+
+func (e ProductAdded) EventType() EventType { return EventProductAdded }
+
+// apply merges the quantity into an existing line of the product, streams recorded before lines were merged
+// may add a product twice.
+func (e ProductAdded) apply(quote *Quote) {
+	if product := quote.product(e.ProductID); product != nil {
+		product.Quantity += e.Quantity
+		return
+	}
+
+	quote.Products = append(quote.Products, Product{
+		ProductID: e.ProductID,
+		Quantity:  e.Quantity,
+	})
+}
+
+func (e ProductQuantityChanged) EventType() EventType { return EventProductQuantityChanged }
+
+func (e ProductQuantityChanged) apply(quote *Quote) {
+	if product := quote.product(e.ProductID); product != nil {
+		product.Quantity = e.Quantity
+	}
+}
+
+func (e ProductRemoved) EventType() EventType { return EventProductRemoved }
+
+func (e ProductRemoved) apply(quote *Quote) {
+	quote.Products = slices.DeleteFunc(quote.Products, func(product Product) bool {
+		return product.ProductID == e.ProductID
+	})
+}
+
+func (e ProductDiscountApplied) EventType() EventType { return EventProductDiscountApplied }
+
+func (e ProductDiscountApplied) apply(quote *Quote) {
+	if product := quote.product(e.ProductID); product != nil {
+		product.Discount = e.Discount
+	}
+}
+
+func (e ProductPriceOverridden) EventType() EventType { return EventProductPriceOverridden }
+
+func (e ProductPriceOverridden) apply(quote *Quote) {
+	if product := quote.product(e.ProductID); product != nil {
+		override := e.Override
+		product.Override = &override
+	}
+}
+
+func (e PriceOverrideDecided) EventType() EventType { return EventPriceOverrideDecided }
+
+func (e PriceOverrideDecided) apply(quote *Quote) {
+	if product := quote.product(e.ProductID); product != nil && product.Override != nil {
+		override := *product.Override
+		override.Status = e.Status
+		override.DecidedBy = e.DecidedBy
+		override.DecidedAt = e.DecidedAt
+		product.Override = &override
+	}
+}
+
+func (e AddressSaved) EventType() EventType { return EventAddressSaved }
+
+func (e AddressSaved) apply(quote *Quote) {
+	quote.Address = e.Address
+}
+
+func (e PaymentSaved) EventType() EventType { return EventPaymentSaved }
+
+func (e PaymentSaved) apply(quote *Quote) {
+	quote.Payment = e.Payment
+}
+
+func (e QuoteRepriced) EventType() EventType { return EventQuoteRepriced }
+
+func (e QuoteRepriced) apply(quote *Quote) {
+	for _, line := range e.Lines {
+		if product := quote.product(line.ProductID); product != nil {
+			product.Amount = line.Amount
+			product.TaxAmount = line.TaxAmount
+			product.TotalAmount = line.TotalAmount
+		}
+	}
+
+	quote.Amount = e.Amount
+	quote.TaxAmount = e.TaxAmount
+	quote.TotalAmount = e.TotalAmount
+}
+
+func (e StatusChanged) EventType() EventType { return EventStatusChanged }
+
+func (e StatusChanged) apply(quote *Quote) {
+	quote.Status = e.Status
+}
+
+func (e QuoteAccepted) EventType() EventType { return EventQuoteAccepted }
+
+func (e QuoteAccepted) apply(quote *Quote) {
+	acceptance := e.Acceptance
+	quote.Acceptance = &acceptance
+	quote.Status = QuoteStatusAccepted
+}
+
+func (e OfferProposed) EventType() EventType { return EventOfferProposed }
+
+// apply counters the open offer, the new offer is the answer to it.
+func (e OfferProposed) apply(quote *Quote) {
+	if open := quote.OpenOffer(); open != nil {
+		open.Status = OfferStatusCountered
+		open.DecidedBy = e.Offer.ProposedBy
+		open.DecidedAt = e.Offer.ProposedAt
+	}
+
+	offer := e.Offer
+	offer.Lines = slices.Clone(e.Offer.Lines)
+	quote.Offers = append(quote.Offers, offer)
+}
+
+func (e OfferAccepted) EventType() EventType { return EventOfferAccepted }
+
+func (e OfferAccepted) apply(quote *Quote) {
+	for i := range quote.Offers {
+		if quote.Offers[i].Number == e.Number {
+			quote.Offers[i].Status = OfferStatusAccepted
+			quote.Offers[i].DecidedBy = e.AcceptedBy
+			quote.Offers[i].DecidedAt = e.AcceptedAt
+
+			// the lines are shared with the copies of the quote, they are changed on a copy of their own
+			quote.Offers[i].Lines = slices.Clone(quote.Offers[i].Lines)
+			for _, productID := range e.Pending {
+				if line := quote.Offers[i].Line(productID); line != nil {
+					line.Approval = ApprovalStatusPending
+				}
+			}
+		}
+	}
+}
+
+func (e OfferLineDecided) EventType() EventType { return EventOfferLineDecided }
+
+func (e OfferLineDecided) apply(quote *Quote) {
+	for i := range quote.Offers {
+		if quote.Offers[i].Number == e.Number {
+			quote.Offers[i].Lines = slices.Clone(quote.Offers[i].Lines)
+			if line := quote.Offers[i].Line(e.ProductID); line != nil {
+				line.Approval = e.Status
+			}
+		}
+	}
+}
+
+// DecodeEventData restores the typed payload of a stored event.
+func DecodeEventData(eventType EventType, data []byte) (EventData, error) {
+	var eventData EventData
+	switch eventType {
+	case EventQuoteCreated:
+		eventData = &QuoteCreated{}
+	case EventQuoteNumbered:
+		eventData = &QuoteNumbered{}
+	case EventDraftNamed:
+		eventData = &DraftNamed{}
+	case EventDraftActivated:
+		eventData = &DraftActivated{}
+	case EventProductAdded:
+		eventData = &ProductAdded{}
+	case EventProductQuantityChanged:
+		eventData = &ProductQuantityChanged{}
+	case EventProductRemoved:
+		eventData = &ProductRemoved{}
+	case EventProductDiscountApplied:
+		eventData = &ProductDiscountApplied{}
+	case EventProductPriceOverridden:
+		eventData = &ProductPriceOverridden{}
+	case EventPriceOverrideDecided:
+		eventData = &PriceOverrideDecided{}
+	case EventAddressSaved:
+		eventData = &AddressSaved{}
+	case EventPaymentSaved:
+		eventData = &PaymentSaved{}
+	case EventQuoteRepriced:
+		eventData = &QuoteRepriced{}
+	case EventStatusChanged:
+		eventData = &StatusChanged{}
+	case EventQuoteAccepted:
+		eventData = &QuoteAccepted{}
+	case EventOfferProposed:
+		eventData = &OfferProposed{}
+	case EventOfferAccepted:
+		eventData = &OfferAccepted{}
+	case EventOfferLineDecided:
+		eventData = &OfferLineDecided{}
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrEventTypeUnknown, eventType)
+	}
+
+	if err := json.Unmarshal(data, eventData); err != nil {
+		return nil, fmt.Errorf("decode %s: %w", eventType, err)
+	}
+
+	return eventData, nil
+}
+
+// product returns the quote line of the product, so it can be changed in place.
+func (q *Quote) product(productID uuid.UUID) *Product {
+	for i := range q.Products {
+		if q.Products[i].ProductID == productID {
+			return &q.Products[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,38 @@
+package types
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteFilter selects a set of quotes, the zero value selects every quote.
+type QuoteFilter struct {
+	// CustomerID restricts the set to the quotes of a customer, uuid.Nil matches every customer.
+	CustomerID uuid.UUID
+	// Statuses restricts the set to quotes in one of the statuses, empty matches every status.
+	Statuses []QuoteStatus
+	// UpdatedFrom is the inclusive lower bound of the last change, the zero time is no bound.
+	UpdatedFrom time.Time
+	// UpdatedTo is the exclusive upper bound of the last change, the zero time is no bound.
+	UpdatedTo time.Time
+}
+
+// Matches reports whether the quote belongs to the set.
+func (f QuoteFilter) Matches(quote *Quote) bool {
+	if f.CustomerID != uuid.Nil && quote.CustomerID != f.CustomerID {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, quote.Status) {
+		return false
+	}
+	if !f.UpdatedFrom.IsZero() && quote.UpdatedAt.Before(f.UpdatedFrom) {
+		return false
+	}
+	if !f.UpdatedTo.IsZero() && !quote.UpdatedAt.Before(f.UpdatedTo) {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was used for another request")
+	ErrIdempotencyKeyInProgress = errors.New("request with the idempotency key is in progress")
+)
+
+// IdempotencyRecord keeps the response of a request sent with an Idempotency-Key header, so a retry
+// of the request is answered with the same response instead of being executed again.
+type IdempotencyRecord struct {
+	// Key is the idempotency key scoped to the client which sent it.
+	Key string
+	// Fingerprint identifies the request method, path and body the key was first used with.
+	Fingerprint string
+	// Completed is false while the first request is still processed.
+	Completed  bool
+	StatusCode int
+	Header     map[string][]string
+	Body       []byte
+	CreatedAt  time.Time
+	ExpiresAt  time.Time
+}
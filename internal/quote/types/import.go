@@ -0,0 +1,12 @@
+package types
+
+import "github.com/google/uuid"
+
+// ImportLine is a quote line read from an uploaded file. The product is given by its ID or by its catalog SKU.
+type ImportLine struct {
+	// Row is the row number in the file, starting with 1 for the header row.
+	Row       int
+	ProductID uuid.UUID
+	SKU       string
+	Quantity  int
+}
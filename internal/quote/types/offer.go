@@ -0,0 +1,89 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OfferSide string
+
+const (
+	OfferSideCustomer OfferSide = "customer"
+	OfferSideSales    OfferSide = "sales"
+)
+
+type OfferStatus string
+
+const (
+	OfferStatusOpen      OfferStatus = "open"
+	OfferStatusAccepted  OfferStatus = "accepted"
+	OfferStatusCountered OfferStatus = "countered"
+)
+
+type (
+	// Offer is a proposal of line prices in the negotiation of a quote between the customer and sales.
+	// The other side accepts it or counters it with an offer of its own, at most one offer is open at a time.
+	Offer struct {
+		// Number is the position of the offer in the thread of the quote, starting from one.
+		Number int
+		Side   OfferSide
+		Lines  []OfferLine
+		Note   string
+		Status OfferStatus
+		// Revision is the quote revision the offer was saved as.
+		Revision   int
+		ProposedBy *Actor
+		ProposedAt time.Time
+		DecidedBy  *Actor
+		DecidedAt  time.Time
+	}
+
+	// OfferLine proposes the unit price and the discount in percent of a product line, lines of products
+	// the offer does not name keep their price.
+	OfferLine struct {
+		ProductID uuid.UUID
+		Price     float64
+		Discount  float64
+		// Approval is the manager decision on an agreed line reducing the catalog price above the approval
+		// threshold, it is empty when the line needs none. A rejected line does not price the quote.
+		Approval ApprovalStatus
+	}
+
+	// OfferCreate asks for a new offer on the draft, the side is given by the actor making it.
+	OfferCreate struct {
+		Lines []OfferLine
+		Note  string
+	}
+)
+
+// OpenOffer returns the offer waiting for an answer, nil when there is none.
+func (q *Quote) OpenOffer() *Offer {
+	if n := len(q.Offers); n > 0 && q.Offers[n-1].Status == OfferStatusOpen {
+		return &q.Offers[n-1]
+	}
+
+	return nil
+}
+
+// AgreedOffer returns the latest accepted offer, its prices replace the prices of the named lines.
+func (q *Quote) AgreedOffer() *Offer {
+	for i := len(q.Offers) - 1; i >= 0; i-- {
+		if q.Offers[i].Status == OfferStatusAccepted {
+			return &q.Offers[i]
+		}
+	}
+
+	return nil
+}
+
+// Line returns the line of the product in the offer, nil when the offer does not name the product.
+func (o *Offer) Line(productID uuid.UUID) *OfferLine {
+	for i := range o.Lines {
+		if o.Lines[i].ProductID == productID {
+			return &o.Lines[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package types
+
+import "github.com/google/uuid"
+
+type ProductOperationType string
+
+const (
+	ProductOperationAdd    ProductOperationType = "add"
+	ProductOperationUpdate ProductOperationType = "update"
+	ProductOperationRemove ProductOperationType = "remove"
+)
+
+// ProductOperation is a single line change of a bulk request. Quantity is ignored by remove.
+type ProductOperation struct {
+	Type      ProductOperationType
+	ProductID uuid.UUID
+	Quantity  int
+}
+
+// ProductOperationResult is the line after the operation at Index was applied, Quantity is zero when it was removed.
+type ProductOperationResult struct {
+	Index     int
+	Type      ProductOperationType
+	ProductID uuid.UUID
+	Quantity  int
+}
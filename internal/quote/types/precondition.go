@@ -0,0 +1,54 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+const (
+	// unsavedETag is the entity tag of a draft which is not stored yet, its id is only assigned when it is saved.
+	unsavedETag string = `"new"`
+	// documentETagSuffix tells the PDF document apart from the JSON representation of the same quote state.
+	documentETagSuffix string = ".pdf"
+)
+
+type preconditionCtx struct{}
+
+// ETag returns the strong entity tag of the quote state, it changes with every recorded event.
+func (q *Quote) ETag() string {
+	if q.Revision == 0 {
+		return unsavedETag
+	}
+
+	return fmt.Sprintf(`"%s.%d"`, q.UUID, q.Version)
+}
+
+// DocumentETag returns the strong entity tag of the PDF document of the quote state. The JSON and the PDF
+// representation must not share a strong validator, so the tag differs from ETag.
+func (q *Quote) DocumentETag() string {
+	etag := q.ETag()
+
+	return etag[:len(etag)-1] + documentETagSuffix + `"`
+}
+
+// MatchesETag reports whether the quote has one of the entity tags. `*` matches every stored quote, a draft
+// which is not stored yet has no current representation (RFC 9110 13.1.1).
+func (q *Quote) MatchesETag(etags []string) bool {
+	if q.Revision > 0 && slices.Contains(etags, "*") {
+		return true
+	}
+
+	return slices.Contains(etags, q.ETag())
+}
+
+// WithExpectedETags returns a copy of the context requiring the changed quote to have one of the entity tags.
+func WithExpectedETags(ctx context.Context, etags []string) context.Context {
+	return context.WithValue(ctx, preconditionCtx{}, etags)
+}
+
+// ExpectedETagsFromContext returns the entity tags stored by WithExpectedETags.
+func ExpectedETagsFromContext(ctx context.Context) ([]string, bool) {
+	etags, ok := ctx.Value(preconditionCtx{}).([]string)
+	return etags, ok
+}
@@ -0,0 +1,110 @@
+package types
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type QuoteSort string
+
+const (
+	QuoteSortUpdatedAtDesc QuoteSort = "-updated_at"
+	QuoteSortUpdatedAtAsc  QuoteSort = "updated_at"
+	QuoteSortCreatedAtDesc QuoteSort = "-created_at"
+	QuoteSortCreatedAtAsc  QuoteSort = "created_at"
+)
+
+// QuoteSorts are the supported orders of a quote query.
+var QuoteSorts = []QuoteSort{QuoteSortUpdatedAtDesc, QuoteSortUpdatedAtAsc, QuoteSortCreatedAtDesc, QuoteSortCreatedAtAsc}
+
+type (
+	// QuoteQuery selects a page of the quotes of a filter in the order of the sort.
+	QuoteQuery struct {
+		Filter QuoteFilter
+		// Sort is the order of the quotes, the most recently changed come first when it is empty.
+		Sort QuoteSort
+		// After is the position of the last quote of the previous page, nil starts at the first quote.
+		After *QuoteCursor
+		Limit int
+	}
+
+	// QuoteCursor is the position of a quote in the order of a query, the sorted time and the ID
+	// of the quote, which orders quotes of the same time.
+	QuoteCursor struct {
+		Time time.Time
+		ID   uuid.UUID
+	}
+
+	// QuotePage are the quotes of a query, Next is the position to continue from, nil on the last page.
+	QuotePage struct {
+		Quotes []*Quote
+		Next   *QuoteCursor
+	}
+)
+
+// Descending reports whether the sort starts with the latest quote.
+func (s QuoteSort) Descending() bool {
+	return strings.HasPrefix(string(s), "-")
+}
+
+// Field is the sorted quote time without the direction, updated_at or created_at.
+func (s QuoteSort) Field() string {
+	return strings.TrimPrefix(string(s), "-")
+}
+
+// CursorOf returns the position of the quote in the order of the query.
+func (q QuoteQuery) CursorOf(quote *Quote) QuoteCursor {
+	if q.Order().Field() == "created_at" {
+		return QuoteCursor{Time: quote.CreatedAt, ID: quote.UUID}
+	}
+
+	return QuoteCursor{Time: quote.UpdatedAt, ID: quote.UUID}
+}
+
+// Page orders the quotes of the filter and cuts the page after the cursor. The quotes are expected to match
+// the filter already, it is meant for stores which can not order and limit by themselves.
+func (q QuoteQuery) Page(quotes []*Quote) *QuotePage {
+	slices.SortFunc(quotes, func(a, b *Quote) int {
+		return q.compare(q.CursorOf(a), q.CursorOf(b))
+	})
+
+	if q.After != nil {
+		quotes = slices.DeleteFunc(quotes, func(quote *Quote) bool {
+			return q.compare(q.CursorOf(quote), *q.After) <= 0
+		})
+	}
+
+	page := &QuotePage{Quotes: quotes}
+	if q.Limit > 0 && len(quotes) > q.Limit {
+		page.Quotes = quotes[:q.Limit]
+		next := q.CursorOf(page.Quotes[q.Limit-1])
+		page.Next = &next
+	}
+
+	return page
+}
+
+// Order is the sort of the query, the most recently changed quote first when no sort is given.
+func (q QuoteQuery) Order() QuoteSort {
+	if q.Sort == "" {
+		return QuoteSortUpdatedAtDesc
+	}
+
+	return q.Sort
+}
+
+// compare orders two positions by the time, positions of the same time by the quote ID.
+func (q QuoteQuery) compare(a, b QuoteCursor) int {
+	c := a.Time.Compare(b.Time)
+	if c == 0 {
+		c = strings.Compare(a.ID.String(), b.ID.String())
+	}
+	if q.Order().Descending() {
+		return -c
+	}
+
+	return c
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,22 +11,68 @@ type QuoteStatus string
 
 const (
 	QuoteStatusDraft      QuoteStatus = "draft"
+	QuoteStatusAccepted   QuoteStatus = "accepted"
 	QuoteStatusProcessing QuoteStatus = "processing"
 	QuoteStatusDone       QuoteStatus = "done"
 )
 
 type Quote struct {
-	UUID        uuid.UUID
-	CustomerID  uuid.UUID
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	Status      QuoteStatus
+	UUID uuid.UUID
+	// Number is the human-readable quote number, e.g. Q-2026-000123. It is assigned on the first save.
+	Number     string
+	CustomerID uuid.UUID
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	UpdatedBy  *Actor
+	Revision   int
+	Version    int
+	Status     QuoteStatus
+	// Name tells drafts of a customer apart, e.g. by the project they are prepared for.
+	Name string
+	// ActivatedAt is when the draft was made the active draft of the customer, zero if it never was.
+	ActivatedAt time.Time
 	Amount      float64
 	TaxAmount   float64
 	TotalAmount float64
 	Address     *Address
 	Payment     *Payment
 	Products    []Product
+	// Offers is the negotiation thread of the quote, oldest first.
+	Offers []Offer
+	// Acceptance is the customer's acceptance of the terms, nil until the quote is accepted. An accepted quote
+	// can not be changed anymore and waits for processing.
+	Acceptance *Acceptance
+	// Changes are the events recorded since the quote was loaded, they are committed by the repository.
+	Changes []Event
+}
+
+// Reference returns the quote number, or the quote ID while the quote has never been saved and has no number.
+func (q *Quote) Reference() string {
+	if q.Number != "" {
+		return q.Number
+	}
+
+	return q.UUID.String()
+}
+
+// HasPendingApprovals reports whether any product line waits for a decision on its price override or on the
+// price agreed in the negotiation.
+func (q *Quote) HasPendingApprovals() bool {
+	for _, product := range q.Products {
+		if product.Override != nil && product.Override.Status == ApprovalStatusPending {
+			return true
+		}
+	}
+
+	if agreed := q.AgreedOffer(); agreed != nil {
+		for _, line := range agreed.Lines {
+			if line.Approval == ApprovalStatusPending {
+				return true
+			}
+		}
+	}
+
+	return false
 }
 
 type Address struct {
@@ -39,13 +86,35 @@ type Payment struct {
 }
 
 type Product struct {
-	ProductID   uuid.UUID
-	Quantity    int
+	ProductID uuid.UUID
+	Quantity  int
+	// Discount is a manual discount in percent applied to the line amount.
+	Discount float64
+	// Override replaces the catalog unit price of the line when it is not rejected.
+	Override    *PriceOverride
 	Amount      float64
 	TaxAmount   float64
 	TotalAmount float64
 }
 
+type ApprovalStatus string
+
+const (
+	ApprovalStatusPending  ApprovalStatus = "pending"
+	ApprovalStatusApproved ApprovalStatus = "approved"
+	ApprovalStatusRejected ApprovalStatus = "rejected"
+)
+
+type PriceOverride struct {
+	Price        float64
+	CatalogPrice float64
+	Status       ApprovalStatus
+	RequestedBy  *Actor
+	RequestedAt  time.Time
+	DecidedBy    *Actor
+	DecidedAt    time.Time
+}
+
 type ProductAdd struct {
 	ProductID uuid.UUID
 	Quantity  int
@@ -55,6 +124,14 @@ type ProductUpdate struct {
 	Quantity int
 }
 
+type ProductDiscount struct {
+	Discount float64
+}
+
+type ProductPriceOverride struct {
+	Price float64
+}
+
 func NewQuote(
 	UUID uuid.UUID,
 	customerID uuid.UUID,
@@ -73,3 +150,9 @@ func NewQuote(
 		Products:    nil,
 	}
 }
+
+// FormatQuoteNumber builds a quote number from the prefix, the year and the sequence of the quote
+// within the year, e.g. Q-2026-000123.
+func FormatQuoteNumber(prefix string, year int, sequence int) string {
+	return fmt.Sprintf("%s-%d-%06d", prefix, year, sequence)
+}
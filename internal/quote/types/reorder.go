@@ -0,0 +1,36 @@
+package types
+
+import "github.com/google/uuid"
+
+type ReorderSkipReason string
+
+const (
+	// ReorderSkipDiscontinued is a product which is not in the catalog anymore.
+	ReorderSkipDiscontinued ReorderSkipReason = "discontinued"
+	// ReorderSkipInvalid is a line the draft does not accept, e.g. as it would exceed the maximum quantity.
+	ReorderSkipInvalid ReorderSkipReason = "invalid"
+)
+
+type (
+	// Reorder copies the lines of the source quote into the draft, the address and the payment only when asked for.
+	Reorder struct {
+		SourceID       uuid.UUID
+		IncludeAddress bool
+		IncludePayment bool
+	}
+
+	// ReorderSkip is the line at Index of the source quote which could not be copied.
+	ReorderSkip struct {
+		Index     int
+		ProductID uuid.UUID
+		Quantity  int
+		Reason    ReorderSkipReason
+		Message   string
+	}
+
+	// ReorderResult are the copied lines indexed like the lines of the source quote and the skipped lines.
+	ReorderResult struct {
+		Copied  []ProductOperationResult
+		Skipped []ReorderSkip
+	}
+)
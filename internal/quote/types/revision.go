@@ -0,0 +1,52 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// QuoteRevision is an immutable snapshot of a quote stored on every save.
+type QuoteRevision struct {
+	QuoteID   uuid.UUID
+	Number    int
+	CreatedAt time.Time
+	CreatedBy *Actor
+	Quote     Quote
+}
+
+type LineChange string
+
+const (
+	LineChangeAdded   LineChange = "added"
+	LineChangeRemoved LineChange = "removed"
+	LineChangeChanged LineChange = "changed"
+)
+
+type QuoteDiff struct {
+	QuoteID     uuid.UUID
+	From        int
+	To          int
+	Lines       []LineDiff
+	Amount      AmountDiff
+	TaxAmount   AmountDiff
+	TotalAmount AmountDiff
+}
+
+type LineDiff struct {
+	ProductID   uuid.UUID
+	Change      LineChange
+	Quantity    QuantityDiff
+	Amount      AmountDiff
+	TotalAmount AmountDiff
+}
+
+type QuantityDiff struct {
+	From int
+	To   int
+}
+
+type AmountDiff struct {
+	From float64
+	To   float64
+}
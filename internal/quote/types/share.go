@@ -0,0 +1,45 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Share is a read-only link to a revision of a quote, e.g. for a purchasing department without an account.
+	// The link itself is a signed token, the share records its expiry and revocation.
+	Share struct {
+		UUID       uuid.UUID
+		QuoteID    uuid.UUID
+		CustomerID uuid.UUID
+		Revision   int
+		CreatedAt  time.Time
+		CreatedBy  *Actor
+		ExpiresAt  time.Time
+		RevokedAt  time.Time
+	}
+
+	// ShareCreate asks for a share of a quote revision, the latest revision and the default duration when
+	// they are zero.
+	ShareCreate struct {
+		Revision int
+		TTL      time.Duration
+	}
+
+	// ShareAccess records a request resolving a share link.
+	ShareAccess struct {
+		ShareID    uuid.UUID
+		AccessedAt time.Time
+		IP         string
+		UserAgent  string
+	}
+)
+
+func (s *Share) Revoked() bool {
+	return !s.RevokedAt.IsZero()
+}
+
+func (s *Share) Expired(now time.Time) bool {
+	return !now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,26 @@
+package types
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type (
+	// Template is a reusable set of quote lines managed by staff, e.g. a starter kit, which customers
+	// apply to their drafts.
+	Template struct {
+		UUID        uuid.UUID
+		Name        string
+		Description string
+		Lines       []TemplateLine
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+		UpdatedBy   *Actor
+	}
+
+	TemplateLine struct {
+		ProductID uuid.UUID `json:"product_id"`
+		Quantity  int       `json:"qty"`
+	}
+)
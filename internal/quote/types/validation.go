@@ -0,0 +1,50 @@
+package types
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrQuoteValidation = errors.New("quote input is invalid")
+)
+
+type (
+	// FieldError describes why the value of a single input field is rejected.
+	FieldError struct {
+		Field   string
+		Message string
+	}
+
+	// ValidationError collects every field error of an input, it matches ErrQuoteValidation.
+	ValidationError struct {
+		Fields []FieldError
+	}
+)
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Field+": "+field.Message)
+	}
+
+	return ErrQuoteValidation.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrQuoteValidation
+}
+
+// Add records a field error.
+func (e *ValidationError) Add(field string, message string) {
+	e.Fields = append(e.Fields, FieldError{Field: field, Message: message})
+}
+
+// Err returns the validation error when a field error was recorded, otherwise nil.
+func (e *ValidationError) Err() error {
+	if len(e.Fields) == 0 {
+		return nil
+	}
+
+	return e
+}
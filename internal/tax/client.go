@@ -3,15 +3,20 @@ package tax
 import (
 	"context"
 	"errors"
+	"fmt"
 )
 
 type Client struct {
 }
 
+var (
+	ErrUnavailable = errors.New("tax service is unavailable")
+)
+
 func NewClient() *Client {
 	return &Client{}
 }
 
 func (t *Client) CalculateTaxes(ctx context.Context, taxRateID string, amount float64) (float64, error) {
-	return 0, errors.New("not implemented")
+	return 0, fmt.Errorf("Tax::Client::CalculateTaxes : %w: not implemented", ErrUnavailable)
 }
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS quote_streams (
+    quote_id    uuid PRIMARY KEY,
+    customer_id uuid    NOT NULL,
+    status      text    NOT NULL,
+    version     integer NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS quote_streams_customer_status_idx ON quote_streams (customer_id, status);
+
+CREATE TABLE IF NOT EXISTS quote_events (
+    quote_id    uuid        NOT NULL REFERENCES quote_streams (quote_id) DEFERRABLE INITIALLY DEFERRED,
+    version     integer     NOT NULL,
+    revision    integer     NOT NULL,
+    type        text        NOT NULL,
+    data        jsonb       NOT NULL,
+    actor       jsonb,
+    occurred_at timestamptz NOT NULL,
+    PRIMARY KEY (quote_id, version)
+);
+
+CREATE TABLE IF NOT EXISTS quote_snapshots (
+    quote_id uuid PRIMARY KEY REFERENCES quote_streams (quote_id),
+    version  integer NOT NULL,
+    data     jsonb   NOT NULL
+);
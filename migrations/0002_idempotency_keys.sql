@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    key         text PRIMARY KEY,
+    fingerprint text        NOT NULL,
+    completed   boolean     NOT NULL DEFAULT false,
+    status_code integer,
+    header      jsonb,
+    body        bytea,
+    created_at  timestamptz NOT NULL,
+    expires_at  timestamptz NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idempotency_keys_expires_at_idx ON idempotency_keys (expires_at);
@@ -0,0 +1,3 @@
+ALTER TABLE quote_streams ADD COLUMN IF NOT EXISTS updated_at timestamptz NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS quote_streams_updated_at_idx ON quote_streams (updated_at, quote_id);
@@ -0,0 +1,10 @@
+ALTER TABLE quote_streams ADD COLUMN IF NOT EXISTS number text;
+
+CREATE UNIQUE INDEX IF NOT EXISTS quote_streams_number_idx ON quote_streams (number);
+
+CREATE TABLE IF NOT EXISTS quote_number_counters (
+    tenant text    NOT NULL,
+    year   integer NOT NULL,
+    value  bigint  NOT NULL,
+    PRIMARY KEY (tenant, year)
+);
@@ -0,0 +1,14 @@
+ALTER TABLE quote_streams ADD COLUMN IF NOT EXISTS created_at timestamptz;
+
+UPDATE quote_streams s
+SET created_at = (e.data ->> 'created_at')::timestamptz
+FROM quote_events e
+WHERE e.quote_id = s.quote_id AND e.type = 'quote_created' AND s.created_at IS NULL;
+
+UPDATE quote_streams SET created_at = updated_at WHERE created_at IS NULL;
+
+ALTER TABLE quote_streams ALTER COLUMN created_at SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS quote_streams_customer_updated_at_idx ON quote_streams (customer_id, updated_at, quote_id);
+
+CREATE INDEX IF NOT EXISTS quote_streams_customer_created_at_idx ON quote_streams (customer_id, created_at, quote_id);
@@ -0,0 +1,4 @@
+ALTER TABLE quote_streams ADD COLUMN IF NOT EXISTS activated_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS quote_streams_customer_status_activated_at_idx
+    ON quote_streams (customer_id, status, activated_at DESC NULLS LAST, updated_at DESC);
@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS quote_templates (
+    id          uuid PRIMARY KEY,
+    name        text        NOT NULL,
+    description text        NOT NULL DEFAULT '',
+    lines       jsonb       NOT NULL,
+    created_at  timestamptz NOT NULL,
+    updated_at  timestamptz NOT NULL,
+    updated_by  jsonb
+);
+
+CREATE INDEX IF NOT EXISTS quote_templates_name_idx ON quote_templates (name, id);
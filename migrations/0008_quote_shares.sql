@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS quote_shares (
+    id          uuid PRIMARY KEY,
+    quote_id    uuid        NOT NULL,
+    customer_id uuid        NOT NULL,
+    revision    integer     NOT NULL,
+    created_at  timestamptz NOT NULL,
+    created_by  jsonb,
+    expires_at  timestamptz NOT NULL,
+    revoked_at  timestamptz
+);
+
+CREATE INDEX IF NOT EXISTS quote_shares_quote_idx ON quote_shares (quote_id, created_at);
+
+CREATE TABLE IF NOT EXISTS quote_share_accesses (
+    share_id    uuid        NOT NULL REFERENCES quote_shares (id),
+    accessed_at timestamptz NOT NULL,
+    ip          text        NOT NULL,
+    user_agent  text        NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS quote_share_accesses_share_idx ON quote_share_accesses (share_id, accessed_at);
@@ -0,0 +1,9 @@
+// Package app holds the assets shared by the application binaries.
+package app
+
+import _ "embed"
+
+// OpenAPISpec is the API contract from Openapi.yaml embedded into the binaries.
+//
+//go:embed Openapi.yaml
+var OpenAPISpec []byte